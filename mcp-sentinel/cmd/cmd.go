@@ -9,8 +9,11 @@ import (
 	"syscall"
 
 	"github.com/jneo8/mcp-sentinel/mcp-sentinel/pkg/config"
+	"github.com/jneo8/mcp-sentinel/mcp-sentinel/pkg/dedup"
 	"github.com/jneo8/mcp-sentinel/mcp-sentinel/pkg/entity"
+	"github.com/jneo8/mcp-sentinel/mcp-sentinel/pkg/ha"
 	"github.com/jneo8/mcp-sentinel/mcp-sentinel/pkg/mcp"
+	"github.com/jneo8/mcp-sentinel/mcp-sentinel/pkg/metrics"
 	"github.com/jneo8/mcp-sentinel/mcp-sentinel/pkg/sentinel"
 	"github.com/jneo8/mcp-sentinel/mcp-sentinel/pkg/watcher"
 	"github.com/rs/zerolog"
@@ -48,11 +51,17 @@ func run(cmd *cobra.Command, args []string) error {
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer cancel()
 
-	// Create watchers from config
-	watchers, err := watcher.CreateWatchersFromConfig(cfg)
+	// Serve Sentinel's own operational telemetry
+	metrics.Serve(ctx, metrics.Config(cfg.Metrics))
+
+	// dedupStore is shared across HA leadership handovers (and, for the
+	// "redis" backend, across replicas), so it's built once here rather
+	// than per-leadership-term inside runAsLeader.
+	dedupStore, err := dedup.NewStore(cfg.Dedup)
 	if err != nil {
-		return fmt.Errorf("failed to create watchers: %w", err)
+		return fmt.Errorf("failed to build dedup store: %w", err)
 	}
+	dedup.ServeAdmin(ctx, cfg.Dedup.AdminListen, dedupStore)
 
 	// Create incident cards from config
 	incidentCards, err := createIncidentCardsFromConfig(cfg)
@@ -60,6 +69,56 @@ func run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create incident cards: %w", err)
 	}
 
+	// When HA is enabled only the elected leader runs watchers/MCP servers;
+	// followers stay idle until they win leadership. With HA disabled this
+	// runs runAsLeader exactly once, matching the previous single-replica behavior.
+	coordinator, err := ha.New(cfg.HA)
+	if err != nil {
+		return fmt.Errorf("failed to set up HA coordinator: %w", err)
+	}
+	ha.ServeAdmin(ctx, cfg.HA.AdminListen, coordinator)
+	go observeHALeader(ctx, coordinator)
+
+	coordinator.Campaign(ctx, func(leaderCtx context.Context) {
+		if err := runAsLeader(leaderCtx, incidentCards, dedupStore); err != nil {
+			log.Error().Err(err).Msg("Sentinel run failed")
+		}
+	}, func() {
+		log.Info().Msg("Lost HA leadership, standing by")
+	})
+
+	log.Info().Msg("MCP Sentinel stopped")
+	return nil
+}
+
+// observeHALeader mirrors ha.Coordinator.Observe() into the sentinel_ha_leader
+// gauge until ctx is cancelled.
+func observeHALeader(ctx context.Context, coordinator ha.Coordinator) {
+	leadership := coordinator.Observe()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case leader, ok := <-leadership:
+			if !ok {
+				return
+			}
+			if leader {
+				metrics.HALeader.Set(1)
+			} else {
+				metrics.HALeader.Set(0)
+			}
+		}
+	}
+}
+
+func runAsLeader(ctx context.Context, incidentCards []entity.IncidentCard, dedupStore dedup.DedupStore) error {
+	// Create watchers from config
+	watchers, err := watcher.CreateWatchersFromConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create watchers: %w", err)
+	}
+
 	// Create and initialize MCP server manager
 	mcpManager := mcp.NewMCPServerManager()
 	if err := mcpManager.InitializeFromConfig(ctx, cfg); err != nil {
@@ -68,7 +127,7 @@ func run(cmd *cobra.Command, args []string) error {
 	defer mcpManager.StopAllServers()
 
 	// Create and start sentinel
-	sentinelSvc, err := sentinel.GetSentinel(watchers, incidentCards, mcpManager, cfg)
+	sentinelSvc, err := sentinel.GetSentinel(watchers, incidentCards, mcpManager, dedupStore, cfg)
 	if err != nil {
 		return fmt.Errorf("failed to create sentinel: %w", err)
 	}
@@ -77,10 +136,9 @@ func run(cmd *cobra.Command, args []string) error {
 		Int("watchers", len(watchers)).
 		Int("incidentCards", len(incidentCards)).
 		Int("mcpServers", len(cfg.MCPServers)).
-		Msg("Starting sentinel")
+		Msg("Starting sentinel as HA leader")
 	sentinelSvc.Run(ctx)
 
-	log.Info().Msg("MCP Sentinel stopped")
 	return nil
 }
 
@@ -165,9 +223,13 @@ func createIncidentCardsFromConfig(cfg config.Config) ([]entity.IncidentCard, er
 			Resource: entity.Resource{
 				Name: cardConfig.Resource, // Bind to resource name
 			},
-			Prompt:        cardConfig.Prompt,
-			Tools:         tools,
-			MaxIterations: maxIterations,
+			Prompt:             cardConfig.Prompt,
+			Tools:              tools,
+			Agent:              cardConfig.Agent,
+			MaxIterations:      maxIterations,
+			ContextBudget:      cardConfig.ContextBudget,
+			SummarizeModel:     cardConfig.SummarizeModel,
+			MaxToolResultBytes: cardConfig.MaxToolResultBytes,
 		}
 		incidentCards = append(incidentCards, card)
 	}
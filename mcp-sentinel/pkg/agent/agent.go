@@ -0,0 +1,386 @@
+// Package agent binds a system prompt, an allow-listed set of MCP tools, and
+// an LLM provider/model into a reusable, task-specialized incident
+// responder. Instead of every tool being globally available to every
+// incident, an IncidentCard names an Agent to run, and multiple cards can
+// share one.
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/jneo8/mcp-sentinel/mcp-sentinel/pkg/agent/budget"
+	"github.com/jneo8/mcp-sentinel/mcp-sentinel/pkg/agent/providers"
+	"github.com/jneo8/mcp-sentinel/mcp-sentinel/pkg/approval"
+	"github.com/jneo8/mcp-sentinel/mcp-sentinel/pkg/config"
+	"github.com/jneo8/mcp-sentinel/mcp-sentinel/pkg/entity"
+	"github.com/jneo8/mcp-sentinel/mcp-sentinel/pkg/mcp"
+	"github.com/jneo8/mcp-sentinel/mcp-sentinel/pkg/metrics"
+	mcpLib "github.com/mark3labs/mcp-go/mcp"
+	"github.com/rs/zerolog/log"
+)
+
+// Agent owns everything needed to investigate one class of incident: a
+// system prompt template, the MCP tools it is allowed to call, a chat
+// completion provider/model, iteration budget, and context files to seed
+// turn 0 with.
+type Agent struct {
+	Name           string
+	PromptTemplate string
+	Tools          []entity.McpTool
+	Model          string
+	MaxIterations  int
+	ContextFiles   []string
+
+	mcpManager *mcp.MCPServerManager
+	provider   providers.ChatCompletionProvider
+}
+
+// New builds an Agent from cfg. globalCfg supplies per-provider credentials
+// (see pkg/agent/providers) and the defaults used when cfg.Model/
+// cfg.MaxIterations are unset.
+func New(cfg config.AgentConfig, mcpManager *mcp.MCPServerManager, globalCfg config.Config) (*Agent, error) {
+	var tools []entity.McpTool
+	for _, toolStr := range cfg.Tools {
+		parts := strings.Split(toolStr, ".")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("agent %s: tool %q must be in \"server-name.tool-name\" format", cfg.Name, toolStr)
+		}
+		tools = append(tools, entity.McpTool{ServerName: parts[0], ToolName: parts[1]})
+	}
+
+	provider, err := providers.New(cfg.Provider, globalCfg)
+	if err != nil {
+		return nil, fmt.Errorf("agent %s: %w", cfg.Name, err)
+	}
+
+	model := cfg.Model
+	if model == "" {
+		model = globalCfg.OpenAIModel
+	}
+	maxIterations := cfg.MaxIterations
+	if maxIterations == 0 {
+		maxIterations = globalCfg.DefaultMaxIterations
+	}
+
+	return &Agent{
+		Name:           cfg.Name,
+		PromptTemplate: cfg.Prompt,
+		Tools:          tools,
+		Model:          model,
+		MaxIterations:  maxIterations,
+		ContextFiles:   cfg.ContextFiles,
+		mcpManager:     mcpManager,
+		provider:       provider,
+	}, nil
+}
+
+// Run investigates card by discovering this agent's allow-listed MCP tools
+// and running a ReAct-style tool-calling conversation, gating every tool
+// call through approver.
+func (a *Agent) Run(ctx context.Context, card entity.IncidentCard, approver approval.ToolApprover) error {
+	log.Info().
+		Str("agent", a.Name).
+		Str("resource", card.Resource.Name).
+		Interface("tools", a.Tools).
+		Msg("Agent investigating incident")
+
+	availableTools, err := a.discoverTools(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to discover MCP tools: %w", err)
+	}
+
+	return a.converse(ctx, card, availableTools, approver)
+}
+
+// toolWithServer pairs an MCP tool definition with the server it was
+// discovered on, so dispatching a later call knows where to send it.
+type toolWithServer struct {
+	mcpLib.Tool
+	ServerName string
+}
+
+func (a *Agent) discoverTools(ctx context.Context) ([]toolWithServer, error) {
+	var availableTools []toolWithServer
+
+	serverToolNames := make(map[string][]string)
+	for _, tool := range a.Tools {
+		serverToolNames[tool.ServerName] = append(serverToolNames[tool.ServerName], tool.ToolName)
+	}
+
+	for serverName, toolNames := range serverToolNames {
+		server, err := a.mcpManager.GetServer(serverName)
+		if err != nil {
+			log.Warn().Str("serverName", serverName).Err(err).Msg("Failed to get MCP server for tool discovery")
+			continue
+		}
+		if server.Client == nil {
+			log.Warn().Str("serverName", serverName).Msg("MCP server client is not initialized")
+			continue
+		}
+
+		listResp, err := server.Client.ListTools(ctx, mcpLib.ListToolsRequest{})
+		if err != nil {
+			log.Warn().Str("serverName", serverName).Err(err).Msg("Failed to list tools from MCP server")
+			continue
+		}
+
+		for _, tool := range listResp.Tools {
+			for _, wanted := range toolNames {
+				if tool.Name == wanted {
+					availableTools = append(availableTools, toolWithServer{Tool: tool, ServerName: serverName})
+					break
+				}
+			}
+		}
+	}
+
+	return availableTools, nil
+}
+
+func (a *Agent) converse(ctx context.Context, card entity.IncidentCard, availableTools []toolWithServer, approver approval.ToolApprover) error {
+	chatTools := toProviderTools(availableTools)
+
+	promptTemplate := a.PromptTemplate
+	if promptTemplate == "" {
+		promptTemplate = DefaultSystemPromptTemplate
+	}
+
+	systemPrompt, err := renderPromptTemplate(promptTemplate, card)
+	if err != nil {
+		return fmt.Errorf("agent %s: %w", a.Name, err)
+	}
+
+	messages := []providers.Message{
+		{Role: "system", Content: systemPrompt},
+	}
+	messages = append(messages, a.contextFileMessages()...)
+	messages = append(messages, providers.Message{Role: "user", Content: DefaultInitialUserPrompt})
+
+	maxIterations := a.MaxIterations
+	if card.MaxIterations != 0 {
+		maxIterations = card.MaxIterations
+	}
+
+	for iteration := 0; iteration < maxIterations; iteration++ {
+		var err error
+		messages, err = budget.Enforce(ctx, a.provider, a.Model, card.SummarizeModel, card.ContextBudget, messages)
+		if err != nil {
+			return fmt.Errorf("failed to enforce context budget: %w", err)
+		}
+
+		reply, err := a.provider.CreateChatCompletion(ctx, providers.Request{
+			Model:    a.Model,
+			Messages: messages,
+			Tools:    chatTools,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get LLM response: %w", err)
+		}
+
+		log.Info().Str("agent", a.Name).Int("iteration", iteration+1).Str("response", reply.Content).Msg("LLM response received")
+
+		if len(reply.ToolCalls) == 0 {
+			messages = append(messages, providers.Message{Role: "assistant", Content: reply.Content})
+			log.Info().Str("agent", a.Name).Str("finalResponse", reply.Content).Msg("Agent investigation finished")
+			return nil
+		}
+
+		messages = append(messages, providers.Message{Role: "assistant", Content: reply.Content, ToolCalls: reply.ToolCalls})
+
+		for _, toolCall := range reply.ToolCalls {
+			messages = append(messages, a.handleToolCall(ctx, toolCall, availableTools, approver, card.MaxToolResultBytes)...)
+		}
+	}
+
+	log.Warn().Str("agent", a.Name).Int("maxIterations", maxIterations).Msg("Agent conversation reached maximum iterations")
+	return nil
+}
+
+// handleToolCall dispatches one tool call and returns the tool message(s) to
+// append to the conversation. A result within maxResultBytes is a single
+// message; a larger one is truncated with a hint, and one more than
+// streamThresholdFactor times over the limit is streamed back as several
+// chunked tool messages.
+func (a *Agent) handleToolCall(ctx context.Context, toolCall providers.ToolCall, availableTools []toolWithServer, approver approval.ToolApprover, maxResultBytes int) []providers.Message {
+	serverName := a.findServerForTool(toolCall.Name, availableTools)
+
+	args := make(map[string]any)
+	if toolCall.Arguments != "" {
+		if err := json.Unmarshal([]byte(toolCall.Arguments), &args); err != nil {
+			log.Error().Err(err).Str("functionName", toolCall.Name).Msg("Failed to parse function arguments")
+		}
+	}
+
+	decision, err := approver.Approve(ctx, serverName, toolCall.Name, args)
+	if err != nil {
+		log.Error().Err(err).Str("functionName", toolCall.Name).Msg("Failed to evaluate tool call approval")
+		return []providers.Message{{Role: "tool", Content: fmt.Sprintf("Tool call approval failed: %v", err), ToolCallID: toolCall.ID}}
+	}
+	if !decision.Approved {
+		log.Warn().Str("functionName", toolCall.Name).Str("reason", decision.Reason).Msg("Tool call denied")
+		return []providers.Message{{Role: "tool", Content: fmt.Sprintf("Tool call denied: %s", decision.Reason), ToolCallID: toolCall.ID}}
+	}
+
+	result, err := a.callTool(ctx, serverName, toolCall.Name, args)
+	if err != nil {
+		log.Error().Err(err).Str("functionName", toolCall.Name).Msg("Failed to execute MCP tool call")
+		return []providers.Message{{Role: "tool", Content: fmt.Sprintf("Tool call failed: %v", err), ToolCallID: toolCall.ID}}
+	}
+
+	return toolResultMessages(result, toolCall.ID, maxResultBytes)
+}
+
+// streamThresholdFactor is how many times over maxResultBytes a result must
+// be before it's streamed back as several chunked tool messages instead of
+// truncated to one with a "call tool.get_full" hint.
+const streamThresholdFactor = 4
+
+func toolResultMessages(result, toolCallID string, maxResultBytes int) []providers.Message {
+	limit := maxResultBytes
+	if limit <= 0 {
+		limit = budget.DefaultMaxToolResultBytes
+	}
+
+	if len(result) <= limit {
+		return []providers.Message{{Role: "tool", Content: result, ToolCallID: toolCallID}}
+	}
+
+	if len(result) <= limit*streamThresholdFactor {
+		return []providers.Message{{Role: "tool", Content: budget.TruncateToolResult(result, limit), ToolCallID: toolCallID}}
+	}
+
+	chunks := budget.ChunkToolResult(result, limit)
+	messages := make([]providers.Message, len(chunks))
+	for i, chunk := range chunks {
+		messages[i] = providers.Message{Role: "tool", Content: chunk, ToolCallID: toolCallID}
+	}
+	return messages
+}
+
+func (a *Agent) findServerForTool(toolName string, availableTools []toolWithServer) string {
+	for _, tool := range availableTools {
+		if tool.Name == toolName {
+			return tool.ServerName
+		}
+	}
+	return ""
+}
+
+func (a *Agent) callTool(ctx context.Context, serverName, toolName string, args map[string]any) (string, error) {
+	start := time.Now()
+	result := "success"
+	defer func() {
+		metrics.MCPToolCallsTotal.WithLabelValues(serverName, toolName, result).Inc()
+		metrics.MCPToolCallDuration.WithLabelValues(serverName, toolName).Observe(time.Since(start).Seconds())
+	}()
+
+	server, err := a.mcpManager.GetServer(serverName)
+	if err != nil {
+		result = "error"
+		return "", fmt.Errorf("failed to get MCP server %s: %w", serverName, err)
+	}
+	if server.Client == nil {
+		result = "error"
+		return "", fmt.Errorf("MCP server %s is not connected", serverName)
+	}
+
+	toolResult, err := server.Client.CallTool(ctx, mcpLib.CallToolRequest{
+		Params: mcpLib.CallToolParams{
+			Name:      toolName,
+			Arguments: args,
+		},
+	})
+	if err != nil {
+		result = "error"
+		return "", fmt.Errorf("failed to call MCP tool %s.%s: %w", serverName, toolName, err)
+	}
+
+	return fmt.Sprintf("%+v", toolResult), nil
+}
+
+// contextFileMessages reads a.ContextFiles and turns each into a system
+// message injected before the first user turn, so an agent can be seeded
+// with runbooks or other static context. Unreadable files are logged and
+// skipped rather than failing the incident.
+func (a *Agent) contextFileMessages() []providers.Message {
+	var messages []providers.Message
+	for _, path := range a.ContextFiles {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			log.Warn().Err(err).Str("agent", a.Name).Str("path", path).Msg("Failed to read agent context file, skipping")
+			continue
+		}
+		messages = append(messages, providers.Message{Role: "system", Content: fmt.Sprintf("Reference material from %s:\n%s", path, content)})
+	}
+	return messages
+}
+
+// promptTemplateData is the data available to an AgentConfig.Prompt
+// template; field names match the placeholders used in
+// DefaultSystemPromptTemplate.
+type promptTemplateData struct {
+	IncidentDetails   string
+	ResourceName      string
+	ResourceType      string
+	ResourceState     string
+	ResourceValue     string
+	ResourceTimestamp string
+}
+
+// renderPromptTemplate executes tmpl as a text/template against card, rather
+// than treating it as an fmt.Sprintf format string: tmpl comes from operator
+// config (AgentConfig.Prompt), and a literal "%" in a hand-written prompt
+// (e.g. "keep error rate below 1%") would otherwise be misread as a format
+// verb.
+func renderPromptTemplate(tmpl string, card entity.IncidentCard) (string, error) {
+	t, err := template.New("system-prompt").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("invalid prompt template: %w", err)
+	}
+
+	var buf strings.Builder
+	err = t.Execute(&buf, promptTemplateData{
+		IncidentDetails:   card.Prompt,
+		ResourceName:      card.Resource.Name,
+		ResourceType:      card.Resource.Type,
+		ResourceState:     card.Resource.State,
+		ResourceValue:     card.Resource.Value,
+		ResourceTimestamp: card.Resource.Timestamp,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to render prompt template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+func toProviderTools(tools []toolWithServer) []providers.Tool {
+	var out []providers.Tool
+	for _, tool := range tools {
+		providerTool := providers.Tool{
+			Name:        tool.Name,
+			Description: tool.Description,
+		}
+
+		if tool.InputSchema.Type != "" {
+			parameters := map[string]any{
+				"type": tool.InputSchema.Type,
+			}
+			if tool.InputSchema.Properties != nil {
+				parameters["properties"] = tool.InputSchema.Properties
+			}
+			if len(tool.InputSchema.Required) > 0 {
+				parameters["required"] = tool.InputSchema.Required
+			}
+			providerTool.Parameters = parameters
+		}
+
+		out = append(out, providerTool)
+	}
+	return out
+}
@@ -0,0 +1,118 @@
+package budget
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jneo8/mcp-sentinel/mcp-sentinel/pkg/agent/providers"
+	"github.com/rs/zerolog/log"
+)
+
+// keepRecentIterations is how many of the most recent assistant/tool-result
+// exchanges Enforce always leaves untouched, so the model keeps the context
+// it most likely still needs.
+const keepRecentIterations = 2
+
+// summarizePrompt instructs the cheap model to condense older tool results.
+const summarizePrompt = "Summarize the following tool results from an ongoing incident investigation into a terse bullet list of the key facts and values an investigator would still need. Drop anything redundant or no longer relevant."
+
+// Enforce returns messages unchanged if their estimated token usage (under
+// model) is within tokenBudget. Otherwise it asks summarizeModel to condense
+// the older tool-result exchanges into a single system-role summary
+// message, keeping the system/user seed and the keepRecentIterations most
+// recent exchanges verbatim.
+func Enforce(ctx context.Context, provider providers.ChatCompletionProvider, model, summarizeModel string, tokenBudget int, messages []providers.Message) ([]providers.Message, error) {
+	if tokenBudget <= 0 {
+		tokenBudget = DefaultContextBudget
+	}
+	if summarizeModel == "" {
+		summarizeModel = model
+	}
+	if CountMessages(model, messages) <= tokenBudget {
+		return messages, nil
+	}
+
+	prefixEnd := seedLength(messages)
+	splitAt := summarizableSplit(messages[prefixEnd:], keepRecentIterations)
+	if splitAt == 0 {
+		// Nothing old enough to summarize away; let the caller hit the
+		// provider's own error rather than summarizing active context.
+		return messages, nil
+	}
+
+	old := messages[prefixEnd : prefixEnd+splitAt]
+	if isSummary(old[0]) && splitAt == 1 {
+		return messages, nil // already a summary; nothing further to do
+	}
+
+	summary, err := summarize(ctx, provider, summarizeModel, old)
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize older tool results: %w", err)
+	}
+
+	out := make([]providers.Message, 0, prefixEnd+1+len(messages)-prefixEnd-splitAt)
+	out = append(out, messages[:prefixEnd]...)
+	out = append(out, providers.Message{Role: "system", Content: summaryPrefix + summary})
+	out = append(out, messages[prefixEnd+splitAt:]...)
+
+	log.Info().
+		Int("summarizedMessages", splitAt).
+		Int("tokensBefore", CountMessages(model, messages)).
+		Int("tokensAfter", CountMessages(model, out)).
+		Msg("Summarized older tool results to stay within context budget")
+
+	return out, nil
+}
+
+// seedLength returns how many leading messages (the system prompt, any
+// context-file system messages, and the initial user turn) must never be
+// summarized away.
+func seedLength(messages []providers.Message) int {
+	i := 0
+	for i < len(messages) && messages[i].Role == "system" {
+		i++
+	}
+	if i < len(messages) && messages[i].Role == "user" {
+		i++
+	}
+	return i
+}
+
+// summarizableSplit returns how many messages at the front of rest can be
+// summarized away while still leaving the last keepIterations assistant
+// turns (and their tool results) intact.
+func summarizableSplit(rest []providers.Message, keepIterations int) int {
+	var assistantIdx []int
+	for i, msg := range rest {
+		if msg.Role == "assistant" {
+			assistantIdx = append(assistantIdx, i)
+		}
+	}
+	if len(assistantIdx) <= keepIterations {
+		return 0
+	}
+	return assistantIdx[len(assistantIdx)-keepIterations]
+}
+
+func summarize(ctx context.Context, provider providers.ChatCompletionProvider, model string, old []providers.Message) (string, error) {
+	var b strings.Builder
+	for _, msg := range old {
+		if msg.Role != "tool" && msg.Content == "" {
+			continue
+		}
+		fmt.Fprintf(&b, "[%s] %s\n", msg.Role, msg.Content)
+	}
+
+	reply, err := provider.CreateChatCompletion(ctx, providers.Request{
+		Model: model,
+		Messages: []providers.Message{
+			{Role: "system", Content: summarizePrompt},
+			{Role: "user", Content: b.String()},
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	return reply.Content, nil
+}
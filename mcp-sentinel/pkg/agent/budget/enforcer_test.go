@@ -0,0 +1,118 @@
+package budget
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/jneo8/mcp-sentinel/mcp-sentinel/pkg/agent/providers"
+)
+
+// fakeProvider is a stub ChatCompletionProvider for tests, analogous to an
+// httptest-backed stub but without the HTTP round trip since
+// ChatCompletionProvider is already an interface seam.
+type fakeProvider struct {
+	reply        providers.Reply
+	err          error
+	lastRequest  providers.Request
+	requestCount int
+}
+
+func (f *fakeProvider) CreateChatCompletion(ctx context.Context, req providers.Request) (providers.Reply, error) {
+	f.lastRequest = req
+	f.requestCount++
+	return f.reply, f.err
+}
+
+func TestEnforceNoOpUnderBudget(t *testing.T) {
+	messages := []providers.Message{
+		{Role: "system", Content: "you are an assistant"},
+		{Role: "user", Content: "investigate"},
+	}
+	provider := &fakeProvider{}
+
+	got, err := Enforce(context.Background(), provider, "gpt-4o", "", 1000, messages)
+	if err != nil {
+		t.Fatalf("Enforce: %v", err)
+	}
+	if len(got) != len(messages) {
+		t.Fatalf("expected messages to pass through unchanged, got %d messages", len(got))
+	}
+	if provider.requestCount != 0 {
+		t.Fatalf("expected no summarization call under budget, got %d calls", provider.requestCount)
+	}
+}
+
+func TestEnforceSummarizesOlderToolResultsOverBudget(t *testing.T) {
+	bigToolResult := strings.Repeat("diagnostic output ", 500)
+
+	messages := []providers.Message{
+		{Role: "system", Content: "you are an assistant"},
+		{Role: "user", Content: "investigate"},
+		{Role: "assistant", ToolCalls: []providers.ToolCall{{ID: "1", Name: "status", Arguments: "{}"}}},
+		{Role: "tool", Content: bigToolResult, ToolCallID: "1"},
+		{Role: "assistant", ToolCalls: []providers.ToolCall{{ID: "2", Name: "status", Arguments: "{}"}}},
+		{Role: "tool", Content: bigToolResult, ToolCallID: "2"},
+		{Role: "assistant", ToolCalls: []providers.ToolCall{{ID: "3", Name: "status", Arguments: "{}"}}},
+		{Role: "tool", Content: bigToolResult, ToolCallID: "3"},
+	}
+
+	provider := &fakeProvider{reply: providers.Reply{Content: "- osd.3 is down"}}
+
+	got, err := Enforce(context.Background(), provider, "gpt-4o", "gpt-4o-mini", 50, messages)
+	if err != nil {
+		t.Fatalf("Enforce: %v", err)
+	}
+	if provider.requestCount != 1 {
+		t.Fatalf("expected exactly one summarization call, got %d", provider.requestCount)
+	}
+	if provider.lastRequest.Model != "gpt-4o-mini" {
+		t.Fatalf("expected summarization to use the cheap model, got %q", provider.lastRequest.Model)
+	}
+
+	// The system prompt and initial user turn must never be summarized away.
+	if got[0].Role != "system" || got[1].Role != "user" {
+		t.Fatalf("expected the seed messages to survive, got roles %q, %q", got[0].Role, got[1].Role)
+	}
+
+	foundSummary := false
+	for _, msg := range got {
+		if isSummary(msg) {
+			foundSummary = true
+			if !strings.Contains(msg.Content, "osd.3 is down") {
+				t.Fatalf("expected summary message to contain the model's summary, got %q", msg.Content)
+			}
+		}
+	}
+	if !foundSummary {
+		t.Fatal("expected a summary message to be present after Enforce")
+	}
+
+	// The most recent exchange should still be present verbatim.
+	if got[len(got)-1].ToolCallID != "3" {
+		t.Fatalf("expected the most recent tool result to survive verbatim, got %+v", got[len(got)-1])
+	}
+}
+
+func TestEnforceLeavesTooFewIterationsAlone(t *testing.T) {
+	// Only one assistant/tool exchange exists, which is within
+	// keepRecentIterations, so there's nothing old enough to summarize.
+	messages := []providers.Message{
+		{Role: "system", Content: "you are an assistant"},
+		{Role: "user", Content: "investigate"},
+		{Role: "assistant", ToolCalls: []providers.ToolCall{{ID: "1", Name: "status", Arguments: "{}"}}},
+		{Role: "tool", Content: strings.Repeat("x", 10000), ToolCallID: "1"},
+	}
+	provider := &fakeProvider{reply: providers.Reply{Content: "summary"}}
+
+	got, err := Enforce(context.Background(), provider, "gpt-4o", "", 10, messages)
+	if err != nil {
+		t.Fatalf("Enforce: %v", err)
+	}
+	if len(got) != len(messages) {
+		t.Fatalf("expected messages to be left untouched, got %d messages", len(got))
+	}
+	if provider.requestCount != 0 {
+		t.Fatalf("expected no summarization call when nothing is old enough to summarize, got %d", provider.requestCount)
+	}
+}
@@ -0,0 +1,62 @@
+// Package budget keeps an Agent's conversation with the LLM within a token
+// budget: it estimates usage per message, truncates or chunks oversized tool
+// results, and summarizes older tool results once the budget is exceeded.
+package budget
+
+import (
+	"strings"
+
+	"github.com/jneo8/mcp-sentinel/mcp-sentinel/pkg/agent/providers"
+	"github.com/pkoukk/tiktoken-go"
+)
+
+// DefaultContextBudget is used when IncidentCard.ContextBudget is unset.
+const DefaultContextBudget = 32000
+
+// DefaultMaxToolResultBytes is used when IncidentCard.MaxToolResultBytes is
+// unset.
+const DefaultMaxToolResultBytes = 8000
+
+// charsPerTokenFallback approximates token count for models tiktoken-go
+// doesn't have an encoding for.
+const charsPerTokenFallback = 4
+
+// CountText estimates how many tokens text costs under model: an exact
+// count via tiktoken-go for known OpenAI models, falling back to
+// len(text)/charsPerTokenFallback for everything else (Anthropic, Google,
+// Ollama, or an unrecognized OpenAI model name).
+func CountText(model, text string) int {
+	enc, err := tiktoken.EncodingForModel(model)
+	if err != nil {
+		return (len(text) + charsPerTokenFallback - 1) / charsPerTokenFallback
+	}
+	return len(enc.Encode(text, nil, nil))
+}
+
+// CountMessage estimates a single message's token cost, including its tool
+// calls.
+func CountMessage(model string, msg providers.Message) int {
+	total := CountText(model, msg.Content)
+	for _, tc := range msg.ToolCalls {
+		total += CountText(model, tc.Name) + CountText(model, tc.Arguments)
+	}
+	return total
+}
+
+// CountMessages sums CountMessage across messages.
+func CountMessages(model string, messages []providers.Message) int {
+	total := 0
+	for _, msg := range messages {
+		total += CountMessage(model, msg)
+	}
+	return total
+}
+
+// summaryPrefix marks a message as budget's own summary so a later Enforce
+// call can tell it apart from a genuine system message and avoid
+// re-summarizing it.
+const summaryPrefix = "Summary of earlier tool results:\n"
+
+func isSummary(msg providers.Message) bool {
+	return msg.Role == "system" && strings.HasPrefix(msg.Content, summaryPrefix)
+}
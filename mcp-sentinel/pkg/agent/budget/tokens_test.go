@@ -0,0 +1,42 @@
+package budget
+
+import (
+	"testing"
+
+	"github.com/jneo8/mcp-sentinel/mcp-sentinel/pkg/agent/providers"
+)
+
+func TestCountTextFallsBackToCharApproximationForUnknownModel(t *testing.T) {
+	text := "0123456789" // 10 chars
+	got := CountText("claude-3-opus", text)
+	want := 3 // ceil(10/4)
+	if got != want {
+		t.Fatalf("CountText(%q) = %d, want %d", text, got, want)
+	}
+}
+
+func TestCountMessagesIncludesToolCalls(t *testing.T) {
+	messages := []providers.Message{
+		{Role: "user", Content: "12345678"}, // 2 tokens
+		{Role: "assistant", ToolCalls: []providers.ToolCall{
+			{Name: "1234", Arguments: "12345678"}, // 1 + 2 tokens
+		}},
+	}
+	got := CountMessages("claude-3-opus", messages)
+	want := 2 + 1 + 2
+	if got != want {
+		t.Fatalf("CountMessages = %d, want %d", got, want)
+	}
+}
+
+func TestIsSummary(t *testing.T) {
+	if !isSummary(providers.Message{Role: "system", Content: summaryPrefix + "bullet points"}) {
+		t.Fatal("expected a system message with the summary prefix to be recognized as a summary")
+	}
+	if isSummary(providers.Message{Role: "system", Content: "unrelated system message"}) {
+		t.Fatal("expected an ordinary system message not to be recognized as a summary")
+	}
+	if isSummary(providers.Message{Role: "user", Content: summaryPrefix + "bullet points"}) {
+		t.Fatal("expected a non-system message not to be recognized as a summary")
+	}
+}
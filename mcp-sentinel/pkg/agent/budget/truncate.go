@@ -0,0 +1,53 @@
+package budget
+
+import "fmt"
+
+// truncatedHint is appended to a tool result cut short by TruncateToolResult,
+// so the model knows more is available rather than assuming the result
+// ended naturally.
+const truncatedHint = "\n…truncated, call tool.get_full for details"
+
+// TruncateToolResult cuts result to maxBytes (falling back to
+// DefaultMaxToolResultBytes when maxBytes <= 0), appending truncatedHint
+// when anything was cut.
+func TruncateToolResult(result string, maxBytes int) string {
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxToolResultBytes
+	}
+	if len(result) <= maxBytes {
+		return result
+	}
+	return result[:maxBytes] + truncatedHint
+}
+
+// ChunkToolResult splits result into pieces of at most maxBytes, for
+// streaming an oversized tool result back to the LLM as several ToolMessages
+// instead of one truncated one. Every chunk is labeled with its position, and
+// the last is also marked final, so the model can tell whether the result is
+// still arriving.
+func ChunkToolResult(result string, maxBytes int) []string {
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxToolResultBytes
+	}
+	if len(result) <= maxBytes {
+		return []string{result}
+	}
+
+	var chunks []string
+	total := (len(result) + maxBytes - 1) / maxBytes
+	for i := 0; i < len(result); i += maxBytes {
+		end := i + maxBytes
+		if end > len(result) {
+			end = len(result)
+		}
+		part := result[i:end]
+		n := len(chunks) + 1
+		if n < total {
+			part = fmt.Sprintf("[chunk %d/%d]\n%s", n, total, part)
+		} else {
+			part = fmt.Sprintf("[chunk %d/%d, final]\n%s", n, total, part)
+		}
+		chunks = append(chunks, part)
+	}
+	return chunks
+}
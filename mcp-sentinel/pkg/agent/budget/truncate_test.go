@@ -0,0 +1,59 @@
+package budget
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTruncateToolResultUnderLimit(t *testing.T) {
+	result := "short result"
+	got := TruncateToolResult(result, 100)
+	if got != result {
+		t.Fatalf("expected short result to pass through unchanged, got %q", got)
+	}
+}
+
+func TestTruncateToolResultOverLimit(t *testing.T) {
+	result := strings.Repeat("x", 100)
+	got := TruncateToolResult(result, 10)
+
+	if !strings.HasPrefix(got, strings.Repeat("x", 10)) {
+		t.Fatalf("expected truncated result to keep the first 10 bytes, got %q", got)
+	}
+	if !strings.Contains(got, "truncated") {
+		t.Fatalf("expected truncated result to carry a hint, got %q", got)
+	}
+}
+
+func TestChunkToolResultUnderLimit(t *testing.T) {
+	result := "short result"
+	chunks := ChunkToolResult(result, 100)
+	if len(chunks) != 1 || chunks[0] != result {
+		t.Fatalf("expected a single unchanged chunk, got %v", chunks)
+	}
+}
+
+func TestChunkToolResultSplitsAndLabelsChunks(t *testing.T) {
+	result := strings.Repeat("x", 25)
+	chunks := ChunkToolResult(result, 10)
+
+	if len(chunks) != 3 {
+		t.Fatalf("expected 3 chunks of a 25-byte result at 10 bytes/chunk, got %d: %v", len(chunks), chunks)
+	}
+	if !strings.Contains(chunks[0], "chunk 1/3") {
+		t.Fatalf("expected first chunk to be labeled 1/3, got %q", chunks[0])
+	}
+	if !strings.Contains(chunks[len(chunks)-1], "final") {
+		t.Fatalf("expected last chunk to be labeled final, got %q", chunks[len(chunks)-1])
+	}
+
+	// No bytes of the original result should be lost across chunks.
+	var rebuilt strings.Builder
+	for _, c := range chunks {
+		lines := strings.SplitN(c, "\n", 2)
+		rebuilt.WriteString(lines[1])
+	}
+	if rebuilt.String() != result {
+		t.Fatalf("expected chunks to reassemble to the original result, got %q", rebuilt.String())
+	}
+}
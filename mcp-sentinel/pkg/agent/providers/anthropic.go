@@ -0,0 +1,171 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+const anthropicAPIURL = "https://api.anthropic.com/v1/messages"
+
+// anthropicProvider implements ChatCompletionProvider against the Anthropic
+// Messages API, using Claude's native tool-use format.
+type anthropicProvider struct {
+	apiKey     string
+	httpClient *http.Client
+}
+
+// NewAnthropic builds a ChatCompletionProvider backed by the Anthropic
+// Messages API.
+func NewAnthropic(apiKey string) ChatCompletionProvider {
+	return &anthropicProvider{apiKey: apiKey, httpClient: http.DefaultClient}
+}
+
+type anthropicRequest struct {
+	Model     string             `json:"model"`
+	MaxTokens int                `json:"max_tokens"`
+	System    string             `json:"system,omitempty"`
+	Messages  []anthropicMessage `json:"messages"`
+	Tools     []anthropicTool    `json:"tools,omitempty"`
+}
+
+type anthropicMessage struct {
+	Role    string             `json:"role"`
+	Content []anthropicContent `json:"content"`
+}
+
+type anthropicContent struct {
+	Type      string         `json:"type"`
+	Text      string         `json:"text,omitempty"`
+	ID        string         `json:"id,omitempty"`
+	Name      string         `json:"name,omitempty"`
+	Input     map[string]any `json:"input,omitempty"`
+	ToolUseID string         `json:"tool_use_id,omitempty"`
+	Content   string         `json:"content,omitempty"`
+}
+
+type anthropicTool struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	InputSchema map[string]any `json:"input_schema"`
+}
+
+type anthropicResponse struct {
+	Content []anthropicContent `json:"content"`
+	Error   *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (p *anthropicProvider) CreateChatCompletion(ctx context.Context, req Request) (Reply, error) {
+	body := anthropicRequest{
+		Model:     req.Model,
+		MaxTokens: 4096,
+		Messages:  toAnthropicMessages(req.Messages),
+		Tools:     toAnthropicTools(req.Tools),
+	}
+	for _, msg := range req.Messages {
+		if msg.Role != "system" {
+			continue
+		}
+		if body.System != "" {
+			body.System += "\n\n"
+		}
+		body.System += msg.Content
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return Reply{}, fmt.Errorf("failed to marshal anthropic request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, anthropicAPIURL, bytes.NewReader(payload))
+	if err != nil {
+		return Reply{}, fmt.Errorf("failed to build anthropic request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("x-api-key", p.apiKey)
+	httpReq.Header.Set("anthropic-version", "2023-06-01")
+
+	resp, err := p.httpClient.Do(httpReq)
+	if err != nil {
+		return Reply{}, fmt.Errorf("anthropic chat completion failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Reply{}, fmt.Errorf("failed to read anthropic response: %w", err)
+	}
+
+	var parsed anthropicResponse
+	if err := json.Unmarshal(respBody, &parsed); err != nil {
+		return Reply{}, fmt.Errorf("failed to decode anthropic response: %w", err)
+	}
+	if parsed.Error != nil {
+		return Reply{}, fmt.Errorf("anthropic chat completion failed: %s", parsed.Error.Message)
+	}
+
+	var reply Reply
+	for _, block := range parsed.Content {
+		switch block.Type {
+		case "text":
+			reply.Content += block.Text
+		case "tool_use":
+			args, err := json.Marshal(block.Input)
+			if err != nil {
+				args = []byte("{}")
+			}
+			reply.ToolCalls = append(reply.ToolCalls, ToolCall{
+				ID:        block.ID,
+				Name:      block.Name,
+				Arguments: string(args),
+			})
+		}
+	}
+	return reply, nil
+}
+
+// toAnthropicMessages drops system messages (passed separately via the
+// top-level "system" field) and folds tool results/tool calls into Claude's
+// content-block format.
+func toAnthropicMessages(messages []Message) []anthropicMessage {
+	var out []anthropicMessage
+	for _, msg := range messages {
+		switch msg.Role {
+		case "system":
+			continue
+		case "user":
+			out = append(out, anthropicMessage{Role: "user", Content: []anthropicContent{{Type: "text", Text: msg.Content}}})
+		case "assistant":
+			var content []anthropicContent
+			if msg.Content != "" {
+				content = append(content, anthropicContent{Type: "text", Text: msg.Content})
+			}
+			for _, toolCall := range msg.ToolCalls {
+				var input map[string]any
+				_ = json.Unmarshal([]byte(toolCall.Arguments), &input)
+				content = append(content, anthropicContent{Type: "tool_use", ID: toolCall.ID, Name: toolCall.Name, Input: input})
+			}
+			out = append(out, anthropicMessage{Role: "assistant", Content: content})
+		case "tool":
+			out = append(out, anthropicMessage{Role: "user", Content: []anthropicContent{{Type: "tool_result", ToolUseID: msg.ToolCallID, Content: msg.Content}}})
+		}
+	}
+	return out
+}
+
+func toAnthropicTools(tools []Tool) []anthropicTool {
+	var out []anthropicTool
+	for _, tool := range tools {
+		out = append(out, anthropicTool{
+			Name:        tool.Name,
+			Description: tool.Description,
+			InputSchema: tool.Parameters,
+		})
+	}
+	return out
+}
@@ -0,0 +1,58 @@
+package providers
+
+import "testing"
+
+func TestToAnthropicMessagesDropsSystemMessages(t *testing.T) {
+	out := toAnthropicMessages([]Message{
+		{Role: "system", Content: "system prompt"},
+		{Role: "user", Content: "hello"},
+	})
+
+	if len(out) != 1 {
+		t.Fatalf("expected the system message to be dropped, got %d messages", len(out))
+	}
+	if out[0].Role != "user" {
+		t.Fatalf("expected the remaining message to be the user turn, got role %q", out[0].Role)
+	}
+}
+
+func TestToAnthropicMessagesSkipsEmptyAssistantText(t *testing.T) {
+	out := toAnthropicMessages([]Message{
+		{Role: "assistant", ToolCalls: []ToolCall{{ID: "1", Name: "status", Arguments: "{}"}}},
+	})
+
+	if len(out) != 1 {
+		t.Fatalf("expected one assistant message, got %d", len(out))
+	}
+	for _, block := range out[0].Content {
+		if block.Type == "text" {
+			t.Fatalf("expected no text block for a tool-only assistant turn, got %+v", out[0].Content)
+		}
+	}
+	if len(out[0].Content) != 1 || out[0].Content[0].Type != "tool_use" {
+		t.Fatalf("expected exactly one tool_use block, got %+v", out[0].Content)
+	}
+}
+
+func TestToAnthropicMessagesKeepsNonEmptyAssistantText(t *testing.T) {
+	out := toAnthropicMessages([]Message{
+		{Role: "assistant", Content: "final answer"},
+	})
+
+	if len(out) != 1 || len(out[0].Content) != 1 || out[0].Content[0].Type != "text" || out[0].Content[0].Text != "final answer" {
+		t.Fatalf("expected a single text block with the assistant's content, got %+v", out)
+	}
+}
+
+func TestToAnthropicMessagesToolResultBecomesUserMessage(t *testing.T) {
+	out := toAnthropicMessages([]Message{
+		{Role: "tool", Content: "42", ToolCallID: "call-1"},
+	})
+
+	if len(out) != 1 || out[0].Role != "user" {
+		t.Fatalf("expected a tool result to become a user message, got %+v", out)
+	}
+	if len(out[0].Content) != 1 || out[0].Content[0].Type != "tool_result" || out[0].Content[0].ToolUseID != "call-1" {
+		t.Fatalf("expected a tool_result block referencing the call, got %+v", out[0].Content)
+	}
+}
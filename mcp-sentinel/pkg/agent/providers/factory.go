@@ -0,0 +1,31 @@
+package providers
+
+import (
+	"fmt"
+
+	"github.com/jneo8/mcp-sentinel/mcp-sentinel/pkg/config"
+)
+
+// New builds the ChatCompletionProvider named by providerName, using
+// per-backend credentials from cfg. An empty providerName defaults to
+// "openai", preserving pre-existing behavior.
+func New(providerName string, cfg config.Config) (ChatCompletionProvider, error) {
+	switch providerName {
+	case "", "openai":
+		return NewOpenAI(cfg.OpenAIURL, cfg.OpenAIAPIKey), nil
+	case "anthropic":
+		if cfg.AnthropicAPIKey == "" {
+			return nil, fmt.Errorf("provider %q requires anthropic-api-key to be set", providerName)
+		}
+		return NewAnthropic(cfg.AnthropicAPIKey), nil
+	case "google":
+		if cfg.GoogleAPIKey == "" {
+			return nil, fmt.Errorf("provider %q requires google-api-key to be set", providerName)
+		}
+		return NewGoogle(cfg.GoogleAPIKey), nil
+	case "ollama":
+		return NewOllama(cfg.OllamaURL), nil
+	default:
+		return nil, fmt.Errorf("unknown chat completion provider: %q", providerName)
+	}
+}
@@ -0,0 +1,189 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+const googleAPIBaseURL = "https://generativelanguage.googleapis.com/v1beta/models"
+
+// googleProvider implements ChatCompletionProvider against the Google
+// Gemini generateContent API.
+type googleProvider struct {
+	apiKey     string
+	httpClient *http.Client
+}
+
+// NewGoogle builds a ChatCompletionProvider backed by the Google Gemini API.
+func NewGoogle(apiKey string) ChatCompletionProvider {
+	return &googleProvider{apiKey: apiKey, httpClient: http.DefaultClient}
+}
+
+type googleRequest struct {
+	SystemInstruction *googleContent  `json:"systemInstruction,omitempty"`
+	Contents          []googleContent `json:"contents"`
+	Tools             []googleTool    `json:"tools,omitempty"`
+}
+
+type googleContent struct {
+	Role  string       `json:"role,omitempty"`
+	Parts []googlePart `json:"parts"`
+}
+
+type googlePart struct {
+	Text             string              `json:"text,omitempty"`
+	FunctionCall     *googleFunctionCall `json:"functionCall,omitempty"`
+	FunctionResponse *googleFuncResponse `json:"functionResponse,omitempty"`
+}
+
+type googleFunctionCall struct {
+	Name string         `json:"name"`
+	Args map[string]any `json:"args"`
+}
+
+type googleFuncResponse struct {
+	Name     string         `json:"name"`
+	Response map[string]any `json:"response"`
+}
+
+type googleTool struct {
+	FunctionDeclarations []googleFunctionDeclaration `json:"functionDeclarations"`
+}
+
+type googleFunctionDeclaration struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Parameters  map[string]any `json:"parameters,omitempty"`
+}
+
+type googleResponse struct {
+	Candidates []struct {
+		Content googleContent `json:"content"`
+	} `json:"candidates"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (p *googleProvider) CreateChatCompletion(ctx context.Context, req Request) (Reply, error) {
+	body := googleRequest{
+		Contents: toGoogleContents(req.Messages),
+	}
+	var systemParts []googlePart
+	for _, msg := range req.Messages {
+		if msg.Role == "system" {
+			systemParts = append(systemParts, googlePart{Text: msg.Content})
+		}
+	}
+	if len(systemParts) > 0 {
+		body.SystemInstruction = &googleContent{Parts: systemParts}
+	}
+	if len(req.Tools) > 0 {
+		body.Tools = []googleTool{{FunctionDeclarations: toGoogleFunctionDeclarations(req.Tools)}}
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return Reply{}, fmt.Errorf("failed to marshal google request: %w", err)
+	}
+
+	endpoint := fmt.Sprintf("%s/%s:generateContent?key=%s", googleAPIBaseURL, req.Model, url.QueryEscape(p.apiKey))
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return Reply{}, fmt.Errorf("failed to build google request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.httpClient.Do(httpReq)
+	if err != nil {
+		return Reply{}, fmt.Errorf("google chat completion failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Reply{}, fmt.Errorf("failed to read google response: %w", err)
+	}
+
+	var parsed googleResponse
+	if err := json.Unmarshal(respBody, &parsed); err != nil {
+		return Reply{}, fmt.Errorf("failed to decode google response: %w", err)
+	}
+	if parsed.Error != nil {
+		return Reply{}, fmt.Errorf("google chat completion failed: %s", parsed.Error.Message)
+	}
+	if len(parsed.Candidates) == 0 {
+		return Reply{}, fmt.Errorf("google chat completion returned no candidates")
+	}
+
+	var reply Reply
+	for _, part := range parsed.Candidates[0].Content.Parts {
+		if part.Text != "" {
+			reply.Content += part.Text
+		}
+		if part.FunctionCall != nil {
+			args, err := json.Marshal(part.FunctionCall.Args)
+			if err != nil {
+				args = []byte("{}")
+			}
+			reply.ToolCalls = append(reply.ToolCalls, ToolCall{
+				// Gemini has no tool-call ID concept; ID carries the
+				// function name so toGoogleContents can match this call to
+				// its result by name.
+				ID:        part.FunctionCall.Name,
+				Name:      part.FunctionCall.Name,
+				Arguments: string(args),
+			})
+		}
+	}
+	return reply, nil
+}
+
+// toGoogleContents drops system messages (passed separately via
+// systemInstruction) and maps tool results/tool calls onto Gemini's
+// "model"/"user" role and functionCall/functionResponse parts. Gemini has no
+// tool-call ID concept, so calls are matched to results by name.
+func toGoogleContents(messages []Message) []googleContent {
+	var out []googleContent
+	for _, msg := range messages {
+		switch msg.Role {
+		case "system":
+			continue
+		case "user":
+			out = append(out, googleContent{Role: "user", Parts: []googlePart{{Text: msg.Content}}})
+		case "assistant":
+			var parts []googlePart
+			if msg.Content != "" {
+				parts = append(parts, googlePart{Text: msg.Content})
+			}
+			for _, toolCall := range msg.ToolCalls {
+				var args map[string]any
+				_ = json.Unmarshal([]byte(toolCall.Arguments), &args)
+				parts = append(parts, googlePart{FunctionCall: &googleFunctionCall{Name: toolCall.Name, Args: args}})
+			}
+			out = append(out, googleContent{Role: "model", Parts: parts})
+		case "tool":
+			out = append(out, googleContent{Role: "user", Parts: []googlePart{{
+				FunctionResponse: &googleFuncResponse{Name: msg.ToolCallID, Response: map[string]any{"result": msg.Content}},
+			}}})
+		}
+	}
+	return out
+}
+
+func toGoogleFunctionDeclarations(tools []Tool) []googleFunctionDeclaration {
+	var out []googleFunctionDeclaration
+	for _, tool := range tools {
+		out = append(out, googleFunctionDeclaration{
+			Name:        tool.Name,
+			Description: tool.Description,
+			Parameters:  tool.Parameters,
+		})
+	}
+	return out
+}
@@ -0,0 +1,45 @@
+package providers
+
+import "testing"
+
+func TestToGoogleContentsDropsSystemMessages(t *testing.T) {
+	out := toGoogleContents([]Message{
+		{Role: "system", Content: "system prompt"},
+		{Role: "user", Content: "hello"},
+	})
+
+	if len(out) != 1 || out[0].Role != "user" {
+		t.Fatalf("expected the system message to be dropped, got %+v", out)
+	}
+}
+
+func TestToGoogleContentsToolResultMatchedByNameNotID(t *testing.T) {
+	// Gemini correlates a tool result to its call by function name: the
+	// provider is expected to carry that name in ToolCallID (see ToolCall.ID
+	// in CreateChatCompletion).
+	out := toGoogleContents([]Message{
+		{Role: "tool", Content: "42", ToolCallID: "get_status"},
+	})
+
+	if len(out) != 1 || len(out[0].Parts) != 1 || out[0].Parts[0].FunctionResponse == nil {
+		t.Fatalf("expected a single functionResponse part, got %+v", out)
+	}
+	if out[0].Parts[0].FunctionResponse.Name != "get_status" {
+		t.Fatalf("expected functionResponse.name to carry the tool call's ID/function name, got %q", out[0].Parts[0].FunctionResponse.Name)
+	}
+}
+
+func TestToGoogleContentsSkipsEmptyAssistantText(t *testing.T) {
+	out := toGoogleContents([]Message{
+		{Role: "assistant", ToolCalls: []ToolCall{{ID: "get_status", Name: "get_status", Arguments: "{}"}}},
+	})
+
+	if len(out) != 1 {
+		t.Fatalf("expected one model message, got %d", len(out))
+	}
+	for _, part := range out[0].Parts {
+		if part.Text != "" {
+			t.Fatalf("expected no text part for a tool-only assistant turn, got %+v", out[0].Parts)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+const defaultOllamaURL = "http://localhost:11434"
+
+// ollamaProvider implements ChatCompletionProvider against a local Ollama
+// server's /api/chat endpoint.
+type ollamaProvider struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewOllama builds a ChatCompletionProvider backed by a local Ollama server.
+// baseURL defaults to http://localhost:11434 when empty.
+func NewOllama(baseURL string) ChatCompletionProvider {
+	if baseURL == "" {
+		baseURL = defaultOllamaURL
+	}
+	return &ollamaProvider{baseURL: baseURL, httpClient: http.DefaultClient}
+}
+
+type ollamaRequest struct {
+	Model    string          `json:"model"`
+	Messages []ollamaMessage `json:"messages"`
+	Tools    []ollamaTool    `json:"tools,omitempty"`
+	Stream   bool            `json:"stream"`
+}
+
+type ollamaMessage struct {
+	Role      string           `json:"role"`
+	Content   string           `json:"content"`
+	ToolCalls []ollamaToolCall `json:"tool_calls,omitempty"`
+}
+
+type ollamaToolCall struct {
+	Function ollamaFunctionCall `json:"function"`
+}
+
+type ollamaFunctionCall struct {
+	Name      string         `json:"name"`
+	Arguments map[string]any `json:"arguments"`
+}
+
+type ollamaTool struct {
+	Type     string             `json:"type"`
+	Function ollamaFunctionSpec `json:"function"`
+}
+
+type ollamaFunctionSpec struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Parameters  map[string]any `json:"parameters,omitempty"`
+}
+
+type ollamaResponse struct {
+	Message ollamaMessage `json:"message"`
+	Error   string        `json:"error,omitempty"`
+}
+
+func (p *ollamaProvider) CreateChatCompletion(ctx context.Context, req Request) (Reply, error) {
+	body := ollamaRequest{
+		Model:    req.Model,
+		Messages: toOllamaMessages(req.Messages),
+		Tools:    toOllamaTools(req.Tools),
+		Stream:   false,
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return Reply{}, fmt.Errorf("failed to marshal ollama request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/api/chat", bytes.NewReader(payload))
+	if err != nil {
+		return Reply{}, fmt.Errorf("failed to build ollama request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.httpClient.Do(httpReq)
+	if err != nil {
+		return Reply{}, fmt.Errorf("ollama chat completion failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Reply{}, fmt.Errorf("failed to read ollama response: %w", err)
+	}
+
+	var parsed ollamaResponse
+	if err := json.Unmarshal(respBody, &parsed); err != nil {
+		return Reply{}, fmt.Errorf("failed to decode ollama response: %w", err)
+	}
+	if parsed.Error != "" {
+		return Reply{}, fmt.Errorf("ollama chat completion failed: %s", parsed.Error)
+	}
+
+	reply := Reply{Content: parsed.Message.Content}
+	for _, toolCall := range parsed.Message.ToolCalls {
+		args, err := json.Marshal(toolCall.Function.Arguments)
+		if err != nil {
+			args = []byte("{}")
+		}
+		reply.ToolCalls = append(reply.ToolCalls, ToolCall{
+			Name:      toolCall.Function.Name,
+			Arguments: string(args),
+		})
+	}
+	return reply, nil
+}
+
+func toOllamaMessages(messages []Message) []ollamaMessage {
+	var out []ollamaMessage
+	for _, msg := range messages {
+		om := ollamaMessage{Role: msg.Role, Content: msg.Content}
+		for _, toolCall := range msg.ToolCalls {
+			var args map[string]any
+			_ = json.Unmarshal([]byte(toolCall.Arguments), &args)
+			om.ToolCalls = append(om.ToolCalls, ollamaToolCall{Function: ollamaFunctionCall{Name: toolCall.Name, Arguments: args}})
+		}
+		out = append(out, om)
+	}
+	return out
+}
+
+func toOllamaTools(tools []Tool) []ollamaTool {
+	var out []ollamaTool
+	for _, tool := range tools {
+		out = append(out, ollamaTool{
+			Type: "function",
+			Function: ollamaFunctionSpec{
+				Name:        tool.Name,
+				Description: tool.Description,
+				Parameters:  tool.Parameters,
+			},
+		})
+	}
+	return out
+}
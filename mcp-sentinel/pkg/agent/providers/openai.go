@@ -0,0 +1,105 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openai/openai-go/v2"
+	"github.com/openai/openai-go/v2/option"
+	"github.com/openai/openai-go/v2/packages/param"
+	"github.com/openai/openai-go/v2/shared"
+)
+
+// openAIProvider implements ChatCompletionProvider against the OpenAI Chat
+// Completions API (and OpenAI-compatible endpoints via baseURL).
+type openAIProvider struct {
+	client openai.Client
+}
+
+// NewOpenAI builds a ChatCompletionProvider backed by the OpenAI Chat
+// Completions API. baseURL overrides the default endpoint when non-empty,
+// for OpenAI-compatible gateways.
+func NewOpenAI(baseURL, apiKey string) ChatCompletionProvider {
+	var opts []option.RequestOption
+	if baseURL != "" {
+		opts = append(opts, option.WithBaseURL(baseURL))
+	}
+	opts = append(opts, option.WithAPIKey(apiKey))
+
+	return &openAIProvider{client: openai.NewClient(opts...)}
+}
+
+func (p *openAIProvider) CreateChatCompletion(ctx context.Context, req Request) (Reply, error) {
+	resp, err := p.client.Chat.Completions.New(ctx, openai.ChatCompletionNewParams{
+		Model:    openai.ChatModel(req.Model),
+		Messages: toOpenAIMessages(req.Messages),
+		Tools:    toOpenAITools(req.Tools),
+	})
+	if err != nil {
+		return Reply{}, fmt.Errorf("openai chat completion failed: %w", err)
+	}
+
+	if len(resp.Choices) == 0 {
+		return Reply{}, fmt.Errorf("openai chat completion returned no choices")
+	}
+
+	choice := resp.Choices[0]
+	reply := Reply{Content: choice.Message.Content}
+	for _, toolCall := range choice.Message.ToolCalls {
+		if toolCall.Type != "function" {
+			continue
+		}
+		reply.ToolCalls = append(reply.ToolCalls, ToolCall{
+			ID:        toolCall.ID,
+			Name:      toolCall.Function.Name,
+			Arguments: toolCall.Function.Arguments,
+		})
+	}
+	return reply, nil
+}
+
+func toOpenAIMessages(messages []Message) []openai.ChatCompletionMessageParamUnion {
+	var out []openai.ChatCompletionMessageParamUnion
+	for _, msg := range messages {
+		switch msg.Role {
+		case "system":
+			out = append(out, openai.SystemMessage(msg.Content))
+		case "user":
+			out = append(out, openai.UserMessage(msg.Content))
+		case "assistant":
+			if len(msg.ToolCalls) == 0 {
+				out = append(out, openai.AssistantMessage(msg.Content))
+				continue
+			}
+			var assistant openai.ChatCompletionAssistantMessageParam
+			assistant.Content.OfString = param.NewOpt(msg.Content)
+			for _, toolCall := range msg.ToolCalls {
+				assistant.ToolCalls = append(assistant.ToolCalls, openai.ChatCompletionMessageToolCallUnionParam{
+					OfFunction: &openai.ChatCompletionMessageFunctionToolCallParam{
+						ID: toolCall.ID,
+						Function: openai.ChatCompletionMessageFunctionToolCallFunctionParam{
+							Name:      toolCall.Name,
+							Arguments: toolCall.Arguments,
+						},
+					},
+				})
+			}
+			out = append(out, openai.ChatCompletionMessageParamUnion{OfAssistant: &assistant})
+		case "tool":
+			out = append(out, openai.ToolMessage(msg.Content, msg.ToolCallID))
+		}
+	}
+	return out
+}
+
+func toOpenAITools(tools []Tool) []openai.ChatCompletionToolUnionParam {
+	var out []openai.ChatCompletionToolUnionParam
+	for _, tool := range tools {
+		out = append(out, openai.ChatCompletionFunctionTool(shared.FunctionDefinitionParam{
+			Name:        tool.Name,
+			Description: openai.String(tool.Description),
+			Parameters:  shared.FunctionParameters(tool.Parameters),
+		}))
+	}
+	return out
+}
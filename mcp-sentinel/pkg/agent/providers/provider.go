@@ -0,0 +1,54 @@
+// Package providers abstracts chat-completion backends (OpenAI, Anthropic,
+// Google Gemini, Ollama) behind a single ChatCompletionProvider interface so
+// Agent isn't hard-wired to any one vendor's SDK or wire format.
+package providers
+
+import "context"
+
+// ChatCompletionProvider runs one chat-completion turn against a backend,
+// returning either assistant text or a normalized set of tool calls.
+type ChatCompletionProvider interface {
+	CreateChatCompletion(ctx context.Context, req Request) (Reply, error)
+}
+
+// Request is a vendor-neutral chat-completion request.
+type Request struct {
+	Model    string
+	Messages []Message
+	Tools    []Tool
+}
+
+// Message is one turn of the conversation. Role is one of "system", "user",
+// "assistant", or "tool". ToolCalls is populated on assistant messages that
+// requested tool calls; ToolCallID is populated on tool messages replying to
+// one of them.
+type Message struct {
+	Role       string
+	Content    string
+	ToolCalls  []ToolCall
+	ToolCallID string
+}
+
+// ToolCall is a normalized function/tool invocation requested by the model.
+// Arguments is the JSON-encoded argument object, matching the wire format
+// every supported backend uses.
+type ToolCall struct {
+	ID        string
+	Name      string
+	Arguments string
+}
+
+// Tool describes one callable tool using JSON Schema parameters, the lowest
+// common denominator across OpenAI, Anthropic, and Gemini tool definitions.
+type Tool struct {
+	Name        string
+	Description string
+	Parameters  map[string]any
+}
+
+// Reply is the model's response for one turn: either final text, or one or
+// more tool calls to execute before continuing the conversation.
+type Reply struct {
+	Content   string
+	ToolCalls []ToolCall
+}
@@ -0,0 +1,62 @@
+// Package approval gates MCP tool calls requested by the LLM behind a
+// pluggable approval policy, so destructive infra operations are not
+// executed without oversight.
+package approval
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Decision reports whether a tool call was approved and why, so a denial
+// reason can be fed back to the LLM as a synthetic tool result.
+type Decision struct {
+	Approved bool
+	Reason   string
+}
+
+// ToolApprover decides whether a requested MCP tool call is allowed to run.
+type ToolApprover interface {
+	Approve(ctx context.Context, serverName, toolName string, args map[string]any) (Decision, error)
+}
+
+// AutoApprove approves every tool call without oversight. It is the default
+// when no approval policy is configured, preserving prior behavior.
+type AutoApprove struct{}
+
+func (AutoApprove) Approve(ctx context.Context, serverName, toolName string, args map[string]any) (Decision, error) {
+	return Decision{Approved: true}, nil
+}
+
+// DenyAll denies every tool call, useful for read-only / dry-run modes.
+type DenyAll struct{}
+
+func (DenyAll) Approve(ctx context.Context, serverName, toolName string, args map[string]any) (Decision, error) {
+	return Decision{Approved: false, Reason: "tool calls are disabled by policy"}, nil
+}
+
+// InteractiveCLI prompts an operator on stdin/stdout before each tool call.
+type InteractiveCLI struct {
+	reader *bufio.Reader
+}
+
+func NewInteractiveCLI() *InteractiveCLI {
+	return &InteractiveCLI{reader: bufio.NewReader(os.Stdin)}
+}
+
+func (c *InteractiveCLI) Approve(ctx context.Context, serverName, toolName string, args map[string]any) (Decision, error) {
+	fmt.Printf("Approve tool call %s.%s with args %+v? [y/N] ", serverName, toolName, args)
+
+	line, err := c.reader.ReadString('\n')
+	if err != nil {
+		return Decision{Approved: false, Reason: fmt.Sprintf("failed to read operator input: %v", err)}, nil
+	}
+
+	if strings.EqualFold(strings.TrimSpace(line), "y") {
+		return Decision{Approved: true}, nil
+	}
+	return Decision{Approved: false, Reason: "denied by operator"}, nil
+}
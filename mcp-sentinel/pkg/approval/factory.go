@@ -0,0 +1,39 @@
+package approval
+
+import (
+	"fmt"
+
+	"github.com/jneo8/mcp-sentinel/mcp-sentinel/pkg/config"
+)
+
+// New builds the ToolApprover configured for an incident card. serverConfigs
+// supplies the per-tool policies referenced when card.Approval is "policy".
+func New(cardCfg config.IncidentCardConfig, serverConfigs []config.MCPServerConfig) (ToolApprover, error) {
+	switch cardCfg.Approval {
+	case "", "auto":
+		return AutoApprove{}, nil
+	case "deny":
+		return DenyAll{}, nil
+	case "interactive":
+		return NewInteractiveCLI(), nil
+	case "webhook":
+		if cardCfg.ApprovalWebhookURL == "" {
+			return nil, fmt.Errorf("incident card %s: approval-webhook-url is required for webhook approval", cardCfg.Name)
+		}
+		return NewWebhookApprover(cardCfg.ApprovalWebhookURL, 0), nil
+	case "policy":
+		var rules []Rule
+		for _, serverCfg := range serverConfigs {
+			for _, policy := range serverCfg.ToolPolicies {
+				rules = append(rules, Rule{
+					Tool:       policy.Tool,
+					ArgPattern: policy.ArgPattern,
+					Mode:       policy.Mode,
+				})
+			}
+		}
+		return NewPolicyApprover(rules, NewInteractiveCLI(), "confirm")
+	default:
+		return nil, fmt.Errorf("incident card %s: unknown approval mode %q", cardCfg.Name, cardCfg.Approval)
+	}
+}
@@ -0,0 +1,96 @@
+package approval
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+)
+
+// Rule matches a tool (and optionally its arguments) to a Mode: "auto",
+// "confirm", or "deny". Rules are evaluated in order; the first match wins.
+type Rule struct {
+	// Tool is matched against the tool name as a regular expression, e.g.
+	// "^ceph\\.status$" or "ceph\\.osd\\.(out|destroy)".
+	Tool string
+	// ArgPattern, if set, must also match the tool call's JSON-encoded
+	// arguments for this rule to apply.
+	ArgPattern string
+	Mode       string
+
+	toolRe *regexp.Regexp
+	argRe  *regexp.Regexp
+}
+
+// PolicyApprover evaluates a Ceph/infra-style allow/confirm/deny rule list.
+// Rules with Mode "confirm" delegate to ConfirmApprover (typically
+// InteractiveCLI or WebhookApprover); rules that match nothing use
+// DefaultMode ("confirm" if unset, the conservative choice).
+type PolicyApprover struct {
+	rules           []Rule
+	confirmApprover ToolApprover
+	defaultMode     string
+}
+
+// NewPolicyApprover compiles rules and returns a PolicyApprover. confirm is
+// used whenever a matched (or default) mode is "confirm".
+func NewPolicyApprover(rules []Rule, confirm ToolApprover, defaultMode string) (*PolicyApprover, error) {
+	compiled := make([]Rule, 0, len(rules))
+	for _, rule := range rules {
+		toolRe, err := regexp.Compile(rule.Tool)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tool pattern %q: %w", rule.Tool, err)
+		}
+		rule.toolRe = toolRe
+
+		if rule.ArgPattern != "" {
+			argRe, err := regexp.Compile(rule.ArgPattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid arg pattern %q: %w", rule.ArgPattern, err)
+			}
+			rule.argRe = argRe
+		}
+
+		compiled = append(compiled, rule)
+	}
+
+	if defaultMode == "" {
+		defaultMode = "confirm"
+	}
+	if confirm == nil {
+		confirm = NewInteractiveCLI()
+	}
+
+	return &PolicyApprover{rules: compiled, confirmApprover: confirm, defaultMode: defaultMode}, nil
+}
+
+func (p *PolicyApprover) Approve(ctx context.Context, serverName, toolName string, args map[string]any) (Decision, error) {
+	mode := p.defaultMode
+
+	argsJSON, err := json.Marshal(args)
+	if err != nil {
+		argsJSON = []byte("{}")
+	}
+
+	for _, rule := range p.rules {
+		if !rule.toolRe.MatchString(toolName) {
+			continue
+		}
+		if rule.argRe != nil && !rule.argRe.Match(argsJSON) {
+			continue
+		}
+		mode = rule.Mode
+		break
+	}
+
+	switch mode {
+	case "auto":
+		return Decision{Approved: true}, nil
+	case "deny":
+		return Decision{Approved: false, Reason: fmt.Sprintf("tool %s.%s is denied by policy", serverName, toolName)}, nil
+	case "confirm":
+		return p.confirmApprover.Approve(ctx, serverName, toolName, args)
+	default:
+		return Decision{Approved: false, Reason: fmt.Sprintf("unknown approval mode %q", mode)}, nil
+	}
+}
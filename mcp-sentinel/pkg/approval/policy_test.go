@@ -0,0 +1,75 @@
+package approval
+
+import (
+	"context"
+	"testing"
+)
+
+func TestPolicyApproverFirstMatchWins(t *testing.T) {
+	rules := []Rule{
+		{Tool: `^ceph\.status$`, Mode: "auto"},
+		{Tool: `ceph\.osd\.(out|destroy)`, Mode: "confirm"},
+	}
+	p, err := NewPolicyApprover(rules, AutoApprove{}, "deny")
+	if err != nil {
+		t.Fatalf("NewPolicyApprover: %v", err)
+	}
+
+	decision, err := p.Approve(context.Background(), "ceph", "ceph.status", nil)
+	if err != nil {
+		t.Fatalf("Approve: %v", err)
+	}
+	if !decision.Approved {
+		t.Fatalf("expected ceph.status to be auto-approved, got %+v", decision)
+	}
+}
+
+func TestPolicyApproverArgPatternMustAlsoMatch(t *testing.T) {
+	rules := []Rule{
+		{Tool: `^ceph\.osd\.out$`, ArgPattern: `"osd_id":"3"`, Mode: "auto"},
+	}
+	p, err := NewPolicyApprover(rules, AutoApprove{}, "deny")
+	if err != nil {
+		t.Fatalf("NewPolicyApprover: %v", err)
+	}
+
+	// Matching args: the rule applies and auto-approves.
+	decision, err := p.Approve(context.Background(), "ceph", "ceph.osd.out", map[string]any{"osd_id": "3"})
+	if err != nil {
+		t.Fatalf("Approve: %v", err)
+	}
+	if !decision.Approved {
+		t.Fatalf("expected matching arg pattern to auto-approve, got %+v", decision)
+	}
+
+	// Non-matching args: falls through to the default "deny".
+	decision, err = p.Approve(context.Background(), "ceph", "ceph.osd.out", map[string]any{"osd_id": "7"})
+	if err != nil {
+		t.Fatalf("Approve: %v", err)
+	}
+	if decision.Approved {
+		t.Fatalf("expected non-matching arg pattern to fall back to default deny, got %+v", decision)
+	}
+}
+
+func TestPolicyApproverDefaultModeConfirm(t *testing.T) {
+	p, err := NewPolicyApprover(nil, DenyAll{}, "")
+	if err != nil {
+		t.Fatalf("NewPolicyApprover: %v", err)
+	}
+
+	decision, err := p.Approve(context.Background(), "ceph", "ceph.status", nil)
+	if err != nil {
+		t.Fatalf("Approve: %v", err)
+	}
+	if decision.Approved {
+		t.Fatalf("expected unmatched tool to fall back to confirm -> DenyAll, got %+v", decision)
+	}
+}
+
+func TestPolicyApproverInvalidPattern(t *testing.T) {
+	_, err := NewPolicyApprover([]Rule{{Tool: "(unclosed"}}, AutoApprove{}, "")
+	if err == nil {
+		t.Fatal("expected an error compiling an invalid tool pattern")
+	}
+}
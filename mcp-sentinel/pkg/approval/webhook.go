@@ -0,0 +1,69 @@
+package approval
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// WebhookApprover delegates the approval decision to an external HTTP
+// endpoint, e.g. a ChatOps bot that asks a human to react with thumbs up/down.
+type WebhookApprover struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewWebhookApprover builds a WebhookApprover that POSTs to url with timeout.
+func NewWebhookApprover(url string, timeout time.Duration) *WebhookApprover {
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	return &WebhookApprover{
+		URL:    url,
+		Client: &http.Client{Timeout: timeout},
+	}
+}
+
+type webhookRequest struct {
+	ServerName string         `json:"serverName"`
+	ToolName   string         `json:"toolName"`
+	Arguments  map[string]any `json:"arguments"`
+}
+
+type webhookResponse struct {
+	Approved bool   `json:"approved"`
+	Reason   string `json:"reason"`
+}
+
+func (w *WebhookApprover) Approve(ctx context.Context, serverName, toolName string, args map[string]any) (Decision, error) {
+	body, err := json.Marshal(webhookRequest{
+		ServerName: serverName,
+		ToolName:   toolName,
+		Arguments:  args,
+	})
+	if err != nil {
+		return Decision{}, fmt.Errorf("failed to marshal webhook approval request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return Decision{}, fmt.Errorf("failed to build webhook approval request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.Client.Do(req)
+	if err != nil {
+		return Decision{}, fmt.Errorf("failed to call approval webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result webhookResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return Decision{}, fmt.Errorf("failed to decode approval webhook response: %w", err)
+	}
+
+	return Decision{Approved: result.Approved, Reason: result.Reason}, nil
+}
@@ -1,5 +1,17 @@
 package config
 
+const (
+	// AppName is the CLI command name and the value logged as the "app"
+	// field at startup.
+	AppName = "mcp-sentinel"
+	// Version is the build version reported in startup logs. It's a fixed
+	// placeholder until the build sets it via -ldflags.
+	Version = "dev"
+	// EnvPrefix is prepended to environment variable names (e.g.
+	// MCP_SENTINEL_OPENAI_API_KEY) bound via viper.AutomaticEnv.
+	EnvPrefix = "MCP_SENTINEL"
+)
+
 type Config struct {
 	// Basic CLI flags
 	Debug   bool
@@ -17,6 +29,13 @@ type Config struct {
 	OpenAIURL            string `mapstructure:"openai-url"`
 	DefaultMaxIterations int    `mapstructure:"default-max-iterations"`
 
+	// Credentials for the other chat-completion providers an agent may
+	// select via AgentConfig.Provider. See pkg/agent/providers.
+	AnthropicAPIKey string `mapstructure:"anthropic-api-key"`
+	GoogleAPIKey    string `mapstructure:"google-api-key"`
+	// OllamaURL defaults to http://localhost:11434 when empty.
+	OllamaURL string `mapstructure:"ollama-url"`
+
 	// Resources - Single source of truth
 	Resources []ResourceConfig `mapstructure:"resources"`
 
@@ -26,8 +45,80 @@ type Config struct {
 	// MCP Servers configuration
 	MCPServers []MCPServerConfig `mapstructure:"mcp-servers"`
 
+	// Agents configuration
+	Agents []AgentConfig `mapstructure:"agents"`
+
 	// Incident Cards configuration
 	IncidentCards []IncidentCardConfig `mapstructure:"incident-cards"`
+
+	// Deduplication configuration
+	Dedup DedupConfig `mapstructure:"dedup"`
+
+	// Metrics configuration
+	Metrics MetricsConfig `mapstructure:"metrics"`
+
+	// High-availability / leader-election configuration
+	HA HAConfig `mapstructure:"ha"`
+}
+
+type HAConfig struct {
+	Enabled       bool   `mapstructure:"enabled"`
+	Backend       string `mapstructure:"backend"` // "file", "redis", or "kubernetes"; "consul" and "etcd" are rejected with an error
+	LeaseName     string `mapstructure:"lease-name"`
+	LeaseDuration string `mapstructure:"lease-duration"`
+
+	// LockFilePath is only used by the "file" backend; defaults to
+	// /tmp/<lease-name>.lock when empty.
+	LockFilePath string `mapstructure:"lock-file-path"`
+
+	// Addrs is used by the "redis" backend, which connects to the first
+	// address only. MasterName (a Redis Sentinel master-set name) is not read
+	// by any backend.
+	Addrs      []string `mapstructure:"addrs"`
+	MasterName string   `mapstructure:"master-name"`
+
+	// Namespace is the Kubernetes namespace holding the coordination.k8s.io
+	// Lease used by the "kubernetes" backend. Defaults to "default".
+	Namespace string `mapstructure:"namespace"`
+
+	// AdminListen is the address an HTTP endpoint listens on for POST /resign,
+	// letting an operator force this replica to step down from leadership
+	// without restarting it. Leave empty to disable the endpoint.
+	AdminListen string `mapstructure:"admin-listen"`
+}
+
+type MetricsConfig struct {
+	// Listen is the address the /metrics endpoint listens on, e.g. ":9095".
+	// Leave empty to disable the endpoint.
+	Listen string `mapstructure:"listen"`
+}
+
+type DedupConfig struct {
+	// RepeatInterval is how long a resolved/unresolved notification is
+	// suppressed for before being re-emitted, e.g. "4h".
+	RepeatInterval string `mapstructure:"repeat-interval"`
+	// MaxEntries bounds how many resource IDs are tracked at once. Shared by
+	// dedup.Deduplicator and the "memory" DedupStore.
+	MaxEntries int `mapstructure:"max-entries"`
+
+	// Backend selects the DedupStore tracking already-investigated
+	// resource IDs, so HA leadership handover doesn't re-fire an incident
+	// already handled: "memory" (default), "bolt", or "redis".
+	Backend string `mapstructure:"backend"`
+	// TTL bounds how long a DedupStore entry is remembered before it expires
+	// and can be re-processed, e.g. "24h". Defaults to dedup.DefaultStoreTTL.
+	TTL string `mapstructure:"ttl"`
+
+	// DBPath is the file path used by the "bolt" backend.
+	DBPath string `mapstructure:"db-path"`
+	// RedisAddr is the address used by the "redis" backend, e.g.
+	// "localhost:6379".
+	RedisAddr string `mapstructure:"redis-addr"`
+
+	// AdminListen exposes an HTTP endpoint ("DELETE /dedup/{id}") letting
+	// operators forget a resource ID to force re-processing without a
+	// restart. Leave empty to disable.
+	AdminListen string `mapstructure:"admin-listen"`
 }
 
 type ResourceConfig struct {
@@ -42,25 +133,52 @@ type WatcherConfig struct {
 	Endpoint     string   `mapstructure:"endpoint"`
 	PollInterval string   `mapstructure:"poll-interval"`
 	Resources    []string `mapstructure:"resources"`
+
+	// Listen is used by push-based watchers (e.g. "alertmanager_webhook")
+	// that run their own HTTP server instead of polling Endpoint.
+	Listen string `mapstructure:"listen"`
 }
 
 type MCPServerConfig struct {
 	Name string `mapstructure:"name"`
-	Type string `mapstructure:"type"` // "stdio" or "streamable"
+	Type string `mapstructure:"type"` // "stdio", "http", or "sse"
 
 	// For stdio servers
 	Command string   `mapstructure:"command"`
 	Args    []string `mapstructure:"args"`
 	WorkDir string   `mapstructure:"work-dir"`
 
-	// For streamable HTTP servers
-	URL string `mapstructure:"url"`
+	// For http (streamable) and sse servers
+	URL         string            `mapstructure:"url"`
+	Headers     map[string]string `mapstructure:"headers"`
+	BearerToken string            `mapstructure:"bearer-token"`
 
 	// Common settings
 	Timeout   string            `mapstructure:"timeout"`
 	Env       map[string]string `mapstructure:"env"`
 	AutoStart bool              `mapstructure:"auto-start"`
 	Tools     []string          `mapstructure:"tools"` // Available tools on this server
+
+	// HealthCheckInterval enables a background Ping loop that restarts the
+	// server (with exponential backoff) if it stops responding. Empty
+	// disables health checking.
+	HealthCheckInterval string `mapstructure:"health-check-interval"`
+
+	// ToolPolicies governs which tools require approval before execution;
+	// see approval.PolicyApprover. Rules match on the tool name only: under
+	// "policy" approval the rules of every server are combined and applied
+	// to tool calls on any server.
+	ToolPolicies []ToolPolicyConfig `mapstructure:"tool-policies"`
+}
+
+type ToolPolicyConfig struct {
+	// Tool is a regular expression matched against the tool name, e.g.
+	// "^status$" or "osd\\.(out|destroy)".
+	Tool string `mapstructure:"tool"`
+	// ArgPattern, if set, must also match the call's JSON-encoded arguments.
+	ArgPattern string `mapstructure:"arg-pattern"`
+	// Mode is "auto", "confirm", or "deny".
+	Mode string `mapstructure:"mode"`
 }
 
 type IncidentCardConfig struct {
@@ -69,6 +187,44 @@ type IncidentCardConfig struct {
 	Prompt        string   `mapstructure:"prompt"`
 	Tools         []string `mapstructure:"tools"`          // Format: "server-name.tool-name"
 	MaxIterations int      `mapstructure:"max-iterations"` // Maximum LLM conversation iterations
+
+	// Agent names the AgentConfig that handles this incident. Multiple
+	// cards may name the same agent. Required.
+	Agent string `mapstructure:"agent"`
+
+	// Approval selects the ToolApprover used for this card's tool calls:
+	// "auto" (default), "deny", "interactive", "webhook", or "policy".
+	Approval string `mapstructure:"approval"`
+	// ApprovalWebhookURL is used when Approval is "webhook".
+	ApprovalWebhookURL string `mapstructure:"approval-webhook-url"`
+
+	// ContextBudget caps the approximate token usage of the conversation
+	// sent to the LLM; older tool results are summarized once it's
+	// exceeded. Zero falls back to budget.DefaultContextBudget.
+	ContextBudget int `mapstructure:"context-budget"`
+	// SummarizeModel is the cheap model used to condense older tool results
+	// when ContextBudget is exceeded. Falls back to the agent's own Model.
+	SummarizeModel string `mapstructure:"summarize-model"`
+	// MaxToolResultBytes bounds a single tool call's result before it's
+	// truncated or chunked into multiple tool messages. Zero falls back to
+	// budget.DefaultMaxToolResultBytes.
+	MaxToolResultBytes int `mapstructure:"max-tool-result-bytes"`
+}
+
+// AgentConfig binds a system prompt, an allow-listed MCP toolset, and an
+// optional model/iteration override into a reusable incident responder that
+// one or more IncidentCardConfigs can reference by Name.
+type AgentConfig struct {
+	Name          string   `mapstructure:"name"`
+	Prompt        string   `mapstructure:"prompt"`         // System prompt template; falls back to agent.DefaultSystemPromptTemplate when empty
+	Tools         []string `mapstructure:"tools"`          // Format: "server-name.tool-name"
+	Provider      string   `mapstructure:"provider"`       // "openai" (default), "anthropic", "google", or "ollama"
+	Model         string   `mapstructure:"model"`          // Overrides the global openai-model when set
+	MaxIterations int      `mapstructure:"max-iterations"` // Falls back to default-max-iterations when zero
+
+	// ContextFiles are read and injected as additional system messages
+	// before the first user turn, e.g. runbooks or playbooks.
+	ContextFiles []string `mapstructure:"context-files"`
 }
 
 func (c *Config) Validate() error {
@@ -0,0 +1,58 @@
+package dedup
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ServeAdmin starts an HTTP endpoint letting operators forget a resource ID
+// from store, forcing it to be re-processed on its next notification without
+// a Sentinel restart. It returns immediately; an empty listen disables it.
+func ServeAdmin(ctx context.Context, listen string, store DedupStore) {
+	if listen == "" {
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/dedup/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		id := strings.TrimPrefix(r.URL.Path, "/dedup/")
+		if id == "" {
+			http.Error(w, "missing resource id", http.StatusBadRequest)
+			return
+		}
+
+		store.Forget(id)
+		log.Info().Str("resourceID", id).Msg("Forgot dedup entry via admin endpoint")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	server := &http.Server{
+		Addr:    listen,
+		Handler: mux,
+	}
+
+	go func() {
+		log.Info().Str("listen", listen).Msg("Starting dedup admin endpoint")
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Error().Err(err).Msg("Dedup admin endpoint failed")
+		}
+	}()
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Warn().Err(err).Msg("Failed to gracefully shut down dedup admin endpoint")
+		}
+	}()
+}
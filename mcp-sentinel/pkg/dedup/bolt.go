@@ -0,0 +1,91 @@
+package dedup
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/jneo8/mcp-sentinel/mcp-sentinel/pkg/metrics"
+	bolt "go.etcd.io/bbolt"
+)
+
+var dedupBucket = []byte("dedup")
+
+// boltStore is a file-backed DedupStore, for single-node persistence across
+// restarts. Each key's value is its expiry time, encoded as an 8-byte
+// big-endian UnixNano; expiry is checked lazily on Seen.
+type boltStore struct {
+	db *bolt.DB
+}
+
+func newBoltStore(path string) (*boltStore, error) {
+	db, err := bolt.Open(path, 0o644, &bolt.Options{Timeout: 3 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open dedup bolt db %s: %w", path, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(dedupBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create dedup bucket: %w", err)
+	}
+
+	return &boltStore{db: db}, nil
+}
+
+func (s *boltStore) Seen(id string) bool {
+	var expiresAt time.Time
+	var found bool
+
+	s.db.View(func(tx *bolt.Tx) error {
+		value := tx.Bucket(dedupBucket).Get([]byte(id))
+		if value == nil {
+			return nil
+		}
+		found = true
+		expiresAt = time.Unix(0, int64(binary.BigEndian.Uint64(value)))
+		return nil
+	})
+
+	if !found {
+		metrics.DedupStoreOpsTotal.WithLabelValues("bolt", "miss").Inc()
+		return false
+	}
+
+	if time.Now().After(expiresAt) {
+		s.Forget(id)
+		metrics.DedupStoreOpsTotal.WithLabelValues("bolt", "evict").Inc()
+		metrics.DedupStoreOpsTotal.WithLabelValues("bolt", "miss").Inc()
+		return false
+	}
+
+	metrics.DedupStoreOpsTotal.WithLabelValues("bolt", "hit").Inc()
+	return true
+}
+
+func (s *boltStore) Mark(id string, ttl time.Duration) {
+	if ttl <= 0 {
+		ttl = DefaultStoreTTL
+	}
+
+	value := make([]byte, 8)
+	binary.BigEndian.PutUint64(value, uint64(time.Now().Add(ttl).UnixNano()))
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(dedupBucket).Put([]byte(id), value)
+	})
+	if err != nil {
+		return
+	}
+	metrics.DedupStoreOpsTotal.WithLabelValues("bolt", "mark").Inc()
+}
+
+func (s *boltStore) Forget(id string) {
+	s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(dedupBucket).Delete([]byte(id))
+	})
+	metrics.DedupStoreOpsTotal.WithLabelValues("bolt", "forget").Inc()
+}
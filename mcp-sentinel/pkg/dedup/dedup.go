@@ -0,0 +1,139 @@
+// Package dedup wraps a notification channel with a bounded, TTL-based cache
+// so that a repeatedly-firing alert does not trigger a fresh incident
+// response on every poll.
+package dedup
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jneo8/mcp-sentinel/mcp-sentinel/pkg/entity"
+	"github.com/jneo8/mcp-sentinel/mcp-sentinel/pkg/metrics"
+	"github.com/rs/zerolog/log"
+)
+
+// DefaultRepeatInterval mirrors Alertmanager's own default repeat_interval.
+const DefaultRepeatInterval = 4 * time.Hour
+
+// DefaultMaxEntries bounds memory use when no explicit limit is configured.
+const DefaultMaxEntries = 10000
+
+type entry struct {
+	id        string
+	expiresAt time.Time
+	resolved  bool
+}
+
+// Deduplicator drops repeat notifications for a resource that has already
+// been seen within RepeatInterval, unless it transitions to resolved or its
+// entry has expired.
+type Deduplicator struct {
+	repeatInterval time.Duration
+	maxEntries     int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List // front = most recently used
+}
+
+// New creates a Deduplicator. A zero repeatInterval or maxEntries falls back
+// to DefaultRepeatInterval / DefaultMaxEntries.
+func New(repeatInterval time.Duration, maxEntries int) *Deduplicator {
+	if repeatInterval <= 0 {
+		repeatInterval = DefaultRepeatInterval
+	}
+	if maxEntries <= 0 {
+		maxEntries = DefaultMaxEntries
+	}
+
+	return &Deduplicator{
+		repeatInterval: repeatInterval,
+		maxEntries:     maxEntries,
+		entries:        make(map[string]*list.Element),
+		order:          list.New(),
+	}
+}
+
+// Wrap returns a channel that forwards everything from in except duplicates,
+// closing the output when in is closed or ctx is cancelled.
+func (d *Deduplicator) Wrap(ctx context.Context, in <-chan entity.Notification) <-chan entity.Notification {
+	out := make(chan entity.Notification, cap(in))
+
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case notification, ok := <-in:
+				if !ok {
+					return
+				}
+				if !d.allow(notification) {
+					metrics.NotificationsDroppedTotal.WithLabelValues("dedup").Inc()
+					log.Debug().
+						Str("resourceID", notification.Resource.ID()).
+						Str("resource", notification.Resource.Name).
+						Msg("Dropped duplicate notification")
+					continue
+				}
+
+				select {
+				case out <- notification:
+				case <-ctx.Done():
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+// allow reports whether notification should be forwarded, recording its
+// resource ID so later duplicates within repeatInterval are dropped.
+func (d *Deduplicator) allow(notification entity.Notification) bool {
+	id := notification.Resource.ID()
+	now := time.Now()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if el, exists := d.entries[id]; exists {
+		ent := el.Value.(*entry)
+		resolvedTransition := notification.Resolved && !ent.resolved
+		expired := now.After(ent.expiresAt)
+
+		if !resolvedTransition && !expired {
+			return false
+		}
+
+		ent.resolved = notification.Resolved
+		ent.expiresAt = now.Add(d.repeatInterval)
+		d.order.MoveToFront(el)
+		return true
+	}
+
+	d.insert(id, notification.Resolved, now)
+	return true
+}
+
+func (d *Deduplicator) insert(id string, resolved bool, now time.Time) {
+	el := d.order.PushFront(&entry{
+		id:        id,
+		expiresAt: now.Add(d.repeatInterval),
+		resolved:  resolved,
+	})
+	d.entries[id] = el
+
+	for d.order.Len() > d.maxEntries {
+		oldest := d.order.Back()
+		if oldest == nil {
+			break
+		}
+		d.order.Remove(oldest)
+		delete(d.entries, oldest.Value.(*entry).id)
+	}
+}
@@ -0,0 +1,56 @@
+package dedup
+
+import (
+	"context"
+	"time"
+
+	"github.com/jneo8/mcp-sentinel/mcp-sentinel/pkg/metrics"
+	"github.com/redis/go-redis/v9"
+)
+
+// redisKeyPrefix namespaces dedup keys so the same Redis instance can be
+// shared with other Sentinel state without colliding.
+const redisKeyPrefix = "mcp-sentinel:dedup:"
+
+// redisStore is a Redis-backed DedupStore, for state shared across replicas
+// regardless of which one processed an incident. TTL is enforced natively
+// via Redis key expiry.
+type redisStore struct {
+	client *redis.Client
+}
+
+func newRedisStore(addr string) *redisStore {
+	return &redisStore{client: redis.NewClient(&redis.Options{Addr: addr})}
+}
+
+func (s *redisStore) Seen(id string) bool {
+	n, err := s.client.Exists(context.Background(), redisKeyPrefix+id).Result()
+	if err != nil {
+		metrics.DedupStoreOpsTotal.WithLabelValues("redis", "miss").Inc()
+		return false
+	}
+
+	if n == 0 {
+		metrics.DedupStoreOpsTotal.WithLabelValues("redis", "miss").Inc()
+		return false
+	}
+
+	metrics.DedupStoreOpsTotal.WithLabelValues("redis", "hit").Inc()
+	return true
+}
+
+func (s *redisStore) Mark(id string, ttl time.Duration) {
+	if ttl <= 0 {
+		ttl = DefaultStoreTTL
+	}
+
+	if err := s.client.Set(context.Background(), redisKeyPrefix+id, "1", ttl).Err(); err != nil {
+		return
+	}
+	metrics.DedupStoreOpsTotal.WithLabelValues("redis", "mark").Inc()
+}
+
+func (s *redisStore) Forget(id string) {
+	s.client.Del(context.Background(), redisKeyPrefix+id)
+	metrics.DedupStoreOpsTotal.WithLabelValues("redis", "forget").Inc()
+}
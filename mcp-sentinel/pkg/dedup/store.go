@@ -0,0 +1,141 @@
+package dedup
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jneo8/mcp-sentinel/mcp-sentinel/pkg/config"
+	"github.com/jneo8/mcp-sentinel/mcp-sentinel/pkg/metrics"
+)
+
+// DefaultStoreTTL is used when DedupConfig.TTL is unset.
+const DefaultStoreTTL = 24 * time.Hour
+
+// DedupStore tracks which resource IDs have already been investigated so
+// that an HA leadership handover, or multiple replicas sharing a backend,
+// doesn't re-fire an incident that was already handled. This is a coarser,
+// longer-lived record than Deduplicator above, which only suppresses
+// repeat notifications within a rolling RepeatInterval.
+type DedupStore interface {
+	// Seen reports whether id has been marked and has not yet expired.
+	Seen(id string) bool
+	// Mark records id as handled until ttl elapses.
+	Mark(id string, ttl time.Duration)
+	// Forget removes id, letting it be re-processed immediately. Operators
+	// use this via the admin endpoint to undo a bad Mark without restarting.
+	Forget(id string)
+}
+
+// NewStore builds the DedupStore configured by cfg.Backend. An empty
+// Backend defaults to "memory".
+func NewStore(cfg config.DedupConfig) (DedupStore, error) {
+	maxEntries := cfg.MaxEntries
+	if maxEntries <= 0 {
+		maxEntries = DefaultMaxEntries
+	}
+
+	switch cfg.Backend {
+	case "", "memory":
+		return newMemoryStore(maxEntries), nil
+	case "bolt":
+		if cfg.DBPath == "" {
+			return nil, fmt.Errorf("dedup backend %q requires db-path to be set", cfg.Backend)
+		}
+		return newBoltStore(cfg.DBPath)
+	case "redis":
+		if cfg.RedisAddr == "" {
+			return nil, fmt.Errorf("dedup backend %q requires redis-addr to be set", cfg.Backend)
+		}
+		return newRedisStore(cfg.RedisAddr), nil
+	default:
+		return nil, fmt.Errorf("unknown dedup backend: %s", cfg.Backend)
+	}
+}
+
+// memoryStore is a process-local, LRU-bounded, TTL-evicting DedupStore, the
+// direct replacement for the sentinel's former unbounded processedAlerts
+// map.
+type memoryStore struct {
+	maxEntries int
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List // front = most recently used
+}
+
+type storeEntry struct {
+	id        string
+	expiresAt time.Time
+}
+
+func newMemoryStore(maxEntries int) *memoryStore {
+	return &memoryStore{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+	}
+}
+
+func (s *memoryStore) Seen(id string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	el, exists := s.entries[id]
+	if !exists {
+		metrics.DedupStoreOpsTotal.WithLabelValues("memory", "miss").Inc()
+		return false
+	}
+
+	if time.Now().After(el.Value.(*storeEntry).expiresAt) {
+		s.order.Remove(el)
+		delete(s.entries, id)
+		metrics.DedupStoreOpsTotal.WithLabelValues("memory", "evict").Inc()
+		metrics.DedupStoreOpsTotal.WithLabelValues("memory", "miss").Inc()
+		return false
+	}
+
+	metrics.DedupStoreOpsTotal.WithLabelValues("memory", "hit").Inc()
+	return true
+}
+
+func (s *memoryStore) Mark(id string, ttl time.Duration) {
+	if ttl <= 0 {
+		ttl = DefaultStoreTTL
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expiresAt := time.Now().Add(ttl)
+	if el, exists := s.entries[id]; exists {
+		el.Value.(*storeEntry).expiresAt = expiresAt
+		s.order.MoveToFront(el)
+	} else {
+		el := s.order.PushFront(&storeEntry{id: id, expiresAt: expiresAt})
+		s.entries[id] = el
+	}
+	metrics.DedupStoreOpsTotal.WithLabelValues("memory", "mark").Inc()
+
+	for s.order.Len() > s.maxEntries {
+		oldest := s.order.Back()
+		if oldest == nil {
+			break
+		}
+		s.order.Remove(oldest)
+		delete(s.entries, oldest.Value.(*storeEntry).id)
+		metrics.DedupStoreOpsTotal.WithLabelValues("memory", "evict").Inc()
+	}
+}
+
+func (s *memoryStore) Forget(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if el, exists := s.entries[id]; exists {
+		s.order.Remove(el)
+		delete(s.entries, id)
+	}
+	metrics.DedupStoreOpsTotal.WithLabelValues("memory", "forget").Inc()
+}
@@ -0,0 +1,66 @@
+package dedup
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemoryStoreSeenMarkForget(t *testing.T) {
+	s := newMemoryStore(10)
+
+	if s.Seen("a") {
+		t.Fatal("expected \"a\" to be unseen before Mark")
+	}
+
+	s.Mark("a", time.Hour)
+	if !s.Seen("a") {
+		t.Fatal("expected \"a\" to be seen after Mark")
+	}
+
+	s.Forget("a")
+	if s.Seen("a") {
+		t.Fatal("expected \"a\" to be unseen after Forget")
+	}
+}
+
+func TestMemoryStoreTTLExpiry(t *testing.T) {
+	s := newMemoryStore(10)
+
+	s.Mark("a", time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+
+	if s.Seen("a") {
+		t.Fatal("expected \"a\" to have expired")
+	}
+}
+
+func TestMemoryStoreLRUEviction(t *testing.T) {
+	s := newMemoryStore(2)
+
+	s.Mark("a", time.Hour)
+	s.Mark("b", time.Hour)
+	s.Mark("c", time.Hour) // evicts "a", the least recently used
+
+	if s.Seen("a") {
+		t.Fatal("expected \"a\" to have been evicted once maxEntries was exceeded")
+	}
+	if !s.Seen("b") || !s.Seen("c") {
+		t.Fatal("expected \"b\" and \"c\" to still be tracked")
+	}
+}
+
+func TestMemoryStoreMarkRefreshesRecency(t *testing.T) {
+	s := newMemoryStore(2)
+
+	s.Mark("a", time.Hour)
+	s.Mark("b", time.Hour)
+	s.Mark("a", time.Hour) // touch "a" again, making "b" the least recently used
+	s.Mark("c", time.Hour) // should evict "b", not "a"
+
+	if s.Seen("b") {
+		t.Fatal("expected \"b\" to have been evicted as least recently used")
+	}
+	if !s.Seen("a") || !s.Seen("c") {
+		t.Fatal("expected \"a\" and \"c\" to still be tracked")
+	}
+}
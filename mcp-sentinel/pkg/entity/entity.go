@@ -13,10 +13,27 @@ type McpTool struct {
 }
 
 type IncidentCard struct {
-	Resource      Resource
-	Prompt        string
-	Tools         []McpTool
+	Resource Resource
+	Prompt   string
+	Tools    []McpTool
+	// Agent names the agent.Agent that should investigate this incident.
+	// When set, it supersedes Tools with the named agent's own toolset and
+	// model. A non-zero MaxIterations on the card takes precedence over the
+	// agent's iteration budget.
+	Agent         string
 	MaxIterations int
+
+	// ContextBudget caps the approximate token usage of the conversation
+	// sent to the LLM; older tool-result messages are summarized once it's
+	// exceeded. Zero falls back to budget.DefaultContextBudget.
+	ContextBudget int
+	// SummarizeModel is the cheap model used to condense older tool results
+	// when ContextBudget is exceeded. Falls back to the agent's own Model.
+	SummarizeModel string
+	// MaxToolResultBytes truncates (or, for large enough results, chunks
+	// into multiple tool messages) a single tool call's result. Zero falls
+	// back to budget.DefaultMaxToolResultBytes.
+	MaxToolResultBytes int
 }
 
 type Resource struct {
@@ -27,11 +44,29 @@ type Resource struct {
 	State       string            `json:"state,omitempty"`
 	Value       string            `json:"value,omitempty"`
 	Timestamp   string            `json:"timestamp,omitempty"`
+
+	// GroupKey and Fingerprint are populated by push-based watchers (e.g.
+	// Alertmanager) that already carry a stable identity for the alert.
+	GroupKey    string `json:"groupKey,omitempty"`
+	Fingerprint string `json:"fingerprint,omitempty"`
 }
 
-// ID generates a unique identifier for the resource based on its type, name, labels, and timestamp
-// This is used for deduplication to prevent processing the same alert multiple times
+// ID generates a stable identifier for the resource based on its type, name,
+// and labels, used to deduplicate repeated firings of the same alert.
+// Timestamp and Value are deliberately excluded: a still-firing alert (e.g.
+// Prometheus) reports a fluctuating Value on every poll, and hashing it in
+// would mint a new ID each time, defeating dedup entirely. Watchers that have
+// no natural per-alert identity of their own and genuinely need per-match
+// identity (file_tail, loki) should populate Fingerprint themselves (see
+// FingerprintFrom) rather than relying on Value here. If the resource carries
+// an Alertmanager Fingerprint, that is used directly since it is already a
+// stable per-alert identity assigned upstream. See OccurrenceKey for an
+// identifier that does vary per-occurrence.
 func (r *Resource) ID() string {
+	if r.Fingerprint != "" {
+		return r.Fingerprint
+	}
+
 	h := sha256.New()
 
 	// Include type and name which are the primary identifiers
@@ -53,15 +88,36 @@ func (r *Resource) ID() string {
 		h.Write([]byte(strings.Join(labelPairs, ",")))
 	}
 
-	// Include timestamp for unique identification
-	if r.Timestamp != "" {
-		h.Write([]byte("|"))
-		h.Write([]byte(r.Timestamp))
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// FingerprintFrom hashes parts into a stable string suitable for
+// Resource.Fingerprint, for watchers whose notifications need per-match
+// identity that ID()'s Type+Name+Labels hash alone can't provide (e.g.
+// file_tail/loki, where two different log lines matching the same pattern
+// are distinct incidents, not repeated firings of one).
+func FingerprintFrom(parts ...string) string {
+	h := sha256.New()
+	h.Write([]byte(strings.Join(parts, "|")))
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// OccurrenceKey generates an identifier like ID but additionally incorporates
+// Timestamp, so each individual firing of an alert gets a distinct key for
+// logging purposes without affecting deduplication.
+func (r *Resource) OccurrenceKey() string {
+	if r.Timestamp == "" {
+		return r.ID()
 	}
 
+	h := sha256.New()
+	h.Write([]byte(r.ID()))
+	h.Write([]byte("|"))
+	h.Write([]byte(r.Timestamp))
 	return fmt.Sprintf("%x", h.Sum(nil))
 }
 
 type Notification struct {
 	Resource Resource
+	Resolved bool
 }
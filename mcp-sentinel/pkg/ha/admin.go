@@ -0,0 +1,52 @@
+package ha
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ServeAdmin starts an HTTP endpoint letting operators force this replica to
+// resign HA leadership, e.g. ahead of a planned drain, without waiting for
+// the lease/lock to expire or restarting the process. It returns
+// immediately; an empty listen disables it.
+func ServeAdmin(ctx context.Context, listen string, coordinator Coordinator) {
+	if listen == "" {
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/resign", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		coordinator.Resign()
+		log.Info().Msg("Resigned HA leadership via admin endpoint")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	server := &http.Server{
+		Addr:    listen,
+		Handler: mux,
+	}
+
+	go func() {
+		log.Info().Str("listen", listen).Msg("Starting HA admin endpoint")
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Error().Err(err).Msg("HA admin endpoint failed")
+		}
+	}()
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Warn().Err(err).Msg("Failed to gracefully shut down HA admin endpoint")
+		}
+	}()
+}
@@ -0,0 +1,175 @@
+package ha
+
+import (
+	"context"
+	"os"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// fileLockCoordinator implements single-writer leader election via an
+// advisory flock on a shared file, suitable for replicas that share a
+// filesystem (e.g. a ReadWriteMany volume).
+type fileLockCoordinator struct {
+	path          string
+	retryInterval time.Duration
+
+	mu       sync.RWMutex
+	file     *os.File
+	isLeader bool
+
+	resignCh  chan struct{}
+	observers []chan bool
+}
+
+func newFileLockCoordinator(path string, retryInterval time.Duration) *fileLockCoordinator {
+	if retryInterval <= 0 {
+		retryInterval = 3 * time.Second
+	}
+	return &fileLockCoordinator{
+		path:          path,
+		retryInterval: retryInterval,
+		resignCh:      make(chan struct{}, 1),
+	}
+}
+
+func (c *fileLockCoordinator) IsLeader() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.isLeader
+}
+
+// Resign requests that this replica step down from leadership (if held) on
+// the next Campaign loop iteration.
+func (c *fileLockCoordinator) Resign() {
+	select {
+	case c.resignCh <- struct{}{}:
+	default:
+	}
+}
+
+// Observe returns a channel fed the current IsLeader() value immediately,
+// and again on every subsequent leadership change.
+func (c *fileLockCoordinator) Observe() <-chan bool {
+	ch := make(chan bool, 1)
+	c.mu.Lock()
+	ch <- c.isLeader
+	c.observers = append(c.observers, ch)
+	c.mu.Unlock()
+	return ch
+}
+
+func (c *fileLockCoordinator) notifyObservers(leader bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, ch := range c.observers {
+		select {
+		case ch <- leader:
+		default:
+		}
+	}
+}
+
+func (c *fileLockCoordinator) Campaign(ctx context.Context, onAcquire func(context.Context), onLose func()) {
+	var leaderCtx context.Context
+	var cancelLeader context.CancelFunc
+	var wg sync.WaitGroup
+
+	becomeLeader := func() {
+		if cancelLeader != nil {
+			return // already running onAcquire
+		}
+		leaderCtx, cancelLeader = context.WithCancel(ctx)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			onAcquire(leaderCtx)
+		}()
+		c.notifyObservers(true)
+	}
+	stepDown := func() {
+		if cancelLeader == nil {
+			return // not currently leader
+		}
+		cancelLeader()
+		// Wait for onAcquire to observe the cancellation and return before
+		// calling onLose/releasing the lock, so another replica can't
+		// acquire leadership while this one still has in-flight work.
+		wg.Wait()
+		onLose()
+		cancelLeader = nil
+		c.notifyObservers(false)
+	}
+
+	ticker := time.NewTicker(c.retryInterval)
+	defer ticker.Stop()
+	defer func() {
+		stepDown()
+		c.release()
+	}()
+
+	c.tryAcquire()
+	if c.IsLeader() {
+		becomeLeader()
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.resignCh:
+			stepDown()
+			c.release()
+		case <-ticker.C:
+			c.tryAcquire()
+			if c.IsLeader() {
+				becomeLeader()
+			} else {
+				stepDown()
+			}
+		}
+	}
+}
+
+func (c *fileLockCoordinator) tryAcquire() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.file != nil {
+		return // already hold the lock
+	}
+
+	f, err := os.OpenFile(c.path, os.O_CREATE|os.O_RDWR, 0o644)
+	if err != nil {
+		log.Warn().Err(err).Str("path", c.path).Msg("Failed to open HA lock file")
+		return
+	}
+
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		f.Close()
+		return
+	}
+
+	c.file = f
+	c.isLeader = true
+	log.Info().Str("path", c.path).Msg("Acquired HA leader lock")
+}
+
+func (c *fileLockCoordinator) release() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.file == nil {
+		return
+	}
+
+	if err := syscall.Flock(int(c.file.Fd()), syscall.LOCK_UN); err != nil {
+		log.Warn().Err(err).Str("path", c.path).Msg("Failed to release HA leader lock")
+	}
+	c.file.Close()
+	c.file = nil
+	c.isLeader = false
+}
@@ -0,0 +1,100 @@
+// Package ha provides single-writer leader election for running multiple
+// Sentinel replicas without duplicating polling and MCP tool-call side
+// effects. Only the elected leader's onAcquire callback runs at a time.
+package ha
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jneo8/mcp-sentinel/mcp-sentinel/pkg/config"
+)
+
+// Coordinator runs a leader-election loop until ctx is done. Whenever this
+// process becomes leader, onAcquire is started in its own goroutine with a
+// context that is cancelled the moment leadership is lost; onLose is then
+// called once that context's cancellation has been observed, so the caller
+// can drain in-flight work before the next onAcquire call.
+type Coordinator interface {
+	Campaign(ctx context.Context, onAcquire func(context.Context), onLose func())
+	IsLeader() bool
+	// Resign voluntarily steps down from leadership, if held, triggering
+	// onLose and releasing the lock/lease for another replica to acquire.
+	Resign()
+	// Observe returns a channel that receives the current IsLeader() value
+	// every time leadership is gained or lost, for callers that want to
+	// react to transitions without threading their own onAcquire/onLose
+	// callbacks through Campaign.
+	Observe() <-chan bool
+}
+
+// New builds a Coordinator from cfg. When HA is disabled, the returned
+// Coordinator always considers itself leader and runs onAcquire immediately,
+// preserving single-replica behavior.
+func New(cfg config.HAConfig) (Coordinator, error) {
+	if !cfg.Enabled {
+		return alwaysLeader{}, nil
+	}
+
+	leaseDuration := 15 * time.Second
+	if cfg.LeaseDuration != "" {
+		parsed, err := time.ParseDuration(cfg.LeaseDuration)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ha lease-duration: %w", err)
+		}
+		leaseDuration = parsed
+	}
+
+	switch cfg.Backend {
+	case "file":
+		lockPath := cfg.LockFilePath
+		if lockPath == "" {
+			lockPath = fmt.Sprintf("/tmp/%s.lock", leaseNameOrDefault(cfg))
+		}
+		// Retry acquisition roughly 4x per lease window.
+		return newFileLockCoordinator(lockPath, leaseDuration/4), nil
+	case "redis":
+		if len(cfg.Addrs) == 0 {
+			return nil, fmt.Errorf("ha backend %q requires at least one address in ha.addrs", cfg.Backend)
+		}
+		// The redis backend is a single-node lock today; MasterName (Redis
+		// Sentinel failover) isn't wired up yet, so only the first address
+		// is used.
+		return newRedisCoordinator(redisCoordinatorConfig{
+			addr:      cfg.Addrs[0],
+			leaseName: leaseNameOrDefault(cfg),
+			ttl:       leaseDuration,
+		}), nil
+	case "kubernetes":
+		return newKubernetesCoordinator(cfg.Namespace, leaseNameOrDefault(cfg), leaseDuration)
+	case "consul", "etcd":
+		return nil, fmt.Errorf("ha backend %q is not yet implemented", cfg.Backend)
+	default:
+		return nil, fmt.Errorf("unknown ha backend: %s", cfg.Backend)
+	}
+}
+
+func leaseNameOrDefault(cfg config.HAConfig) string {
+	if cfg.LeaseName != "" {
+		return cfg.LeaseName
+	}
+	return "mcp-sentinel"
+}
+
+// alwaysLeader is the no-op Coordinator used when HA is disabled.
+type alwaysLeader struct{}
+
+func (alwaysLeader) Campaign(ctx context.Context, onAcquire func(context.Context), onLose func()) {
+	onAcquire(ctx)
+}
+
+func (alwaysLeader) IsLeader() bool { return true }
+
+func (alwaysLeader) Resign() {}
+
+func (alwaysLeader) Observe() <-chan bool {
+	ch := make(chan bool, 1)
+	ch <- true
+	return ch
+}
@@ -0,0 +1,200 @@
+package ha
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+// kubernetesCoordinator implements single-writer leader election via a
+// coordination.k8s.io Lease, for replicas running as separate Kubernetes
+// pods with no shared filesystem or external lock service.
+type kubernetesCoordinator struct {
+	client    kubernetes.Interface
+	namespace string
+	leaseName string
+	identity  string
+	ttl       time.Duration
+
+	mu       sync.RWMutex
+	isLeader bool
+
+	resignCh  chan struct{}
+	observers []chan bool
+}
+
+func newKubernetesCoordinator(namespace, leaseName string, ttl time.Duration) (*kubernetesCoordinator, error) {
+	restCfg, err := kubeRESTConfig()
+	if err != nil {
+		return nil, fmt.Errorf("building kubernetes client config: %w", err)
+	}
+
+	client, err := kubernetes.NewForConfig(restCfg)
+	if err != nil {
+		return nil, fmt.Errorf("building kubernetes client: %w", err)
+	}
+
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	return &kubernetesCoordinator{
+		client:    client,
+		namespace: namespace,
+		leaseName: leaseName,
+		identity:  newIdentity(),
+		ttl:       ttl,
+		resignCh:  make(chan struct{}, 1),
+	}, nil
+}
+
+// kubeRESTConfig uses the in-cluster config when running as a pod, falling
+// back to the local kubeconfig for development.
+func kubeRESTConfig() (*rest.Config, error) {
+	if cfg, err := rest.InClusterConfig(); err == nil {
+		return cfg, nil
+	}
+
+	kubeconfig := os.Getenv("KUBECONFIG")
+	if kubeconfig == "" {
+		home, _ := os.UserHomeDir()
+		kubeconfig = home + "/.kube/config"
+	}
+	return clientcmd.BuildConfigFromFlags("", kubeconfig)
+}
+
+func (c *kubernetesCoordinator) IsLeader() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.isLeader
+}
+
+func (c *kubernetesCoordinator) Resign() {
+	select {
+	case c.resignCh <- struct{}{}:
+	default:
+	}
+}
+
+func (c *kubernetesCoordinator) Observe() <-chan bool {
+	ch := make(chan bool, 1)
+	c.mu.Lock()
+	ch <- c.isLeader
+	c.observers = append(c.observers, ch)
+	c.mu.Unlock()
+	return ch
+}
+
+func (c *kubernetesCoordinator) notifyObservers(leader bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, ch := range c.observers {
+		select {
+		case ch <- leader:
+		default:
+		}
+	}
+}
+
+func (c *kubernetesCoordinator) setLeader(leader bool) {
+	c.mu.Lock()
+	c.isLeader = leader
+	c.mu.Unlock()
+	c.notifyObservers(leader)
+}
+
+// Campaign wraps client-go's leaderelection.LeaderElector, whose
+// OnStartedLeading/OnStoppedLeading callbacks don't otherwise match
+// Coordinator's drain-then-onLose contract: OnStoppedLeading fires the
+// moment the Lease is lost, with no guarantee the OnStartedLeading goroutine
+// has returned. We bridge that with the same onAcquire-context-cancel +
+// wg.Wait() pattern used by the file and redis backends.
+//
+// Resign is implemented by cancelling the elector's run loop and
+// re-entering it: leaderelection has no built-in voluntary step-down, but
+// exiting Run releases the lease (via ReleaseOnCancel) and a fresh Run call
+// re-enters the election as a normal candidate.
+func (c *kubernetesCoordinator) Campaign(ctx context.Context, onAcquire func(context.Context), onLose func()) {
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      c.leaseName,
+			Namespace: c.namespace,
+		},
+		Client: c.client.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity: c.identity,
+		},
+	}
+
+	for {
+		electionCtx, cancelElection := context.WithCancel(ctx)
+
+		var leaderCtx context.Context
+		var cancelLeader context.CancelFunc
+		var wg sync.WaitGroup
+
+		elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+			Lock:            lock,
+			ReleaseOnCancel: true,
+			LeaseDuration:   c.ttl,
+			RenewDeadline:   c.ttl * 2 / 3,
+			RetryPeriod:     c.ttl / 4,
+			Callbacks: leaderelection.LeaderCallbacks{
+				OnStartedLeading: func(leCtx context.Context) {
+					leaderCtx, cancelLeader = context.WithCancel(leCtx)
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						onAcquire(leaderCtx)
+					}()
+					c.setLeader(true)
+				},
+				OnStoppedLeading: func() {
+					// client-go calls this whenever Run exits, even if this
+					// round never acquired the Lease.
+					if cancelLeader == nil {
+						return
+					}
+					cancelLeader()
+					wg.Wait()
+					onLose()
+					c.setLeader(false)
+				},
+			},
+		})
+		if err != nil {
+			cancelElection()
+			log.Error().Err(err).Msg("Failed to build Kubernetes leader elector")
+			return
+		}
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			elector.Run(electionCtx)
+		}()
+
+		select {
+		case <-ctx.Done():
+			cancelElection()
+			<-done
+			return
+		case <-c.resignCh:
+			// Cancelling Run releases the lease (ReleaseOnCancel) and fires
+			// OnStoppedLeading if we were leading; loop back around to
+			// re-enter the election as a fresh candidate.
+			cancelElection()
+			<-done
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package ha
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+)
+
+// redisKeyPrefix namespaces the HA lock key, matching the convention used by
+// pkg/dedup's Redis store.
+const redisKeyPrefix = "mcp-sentinel:ha:"
+
+// releaseScript deletes the lock key only if it is still held by this
+// identity, so a replica can never release a lock another replica has since
+// acquired (e.g. after this one stalled past the lease TTL).
+var releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// renewScript extends the lock key's TTL only if it is still held by this
+// identity, so renewing can never take over a lock another replica holds.
+var renewScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+// redisCoordinator implements single-writer leader election via a Redis
+// SET NX PX lock, for replicas that don't share a filesystem (e.g. separate
+// Kubernetes pods without a shared volume).
+type redisCoordinator struct {
+	client   *redis.Client
+	key      string
+	identity string
+	ttl      time.Duration
+	interval time.Duration
+
+	mu       sync.RWMutex
+	isLeader bool
+
+	resignCh  chan struct{}
+	observers []chan bool
+}
+
+func newRedisCoordinator(cfg redisCoordinatorConfig) *redisCoordinator {
+	interval := cfg.ttl / 4
+	if interval <= 0 {
+		interval = 3 * time.Second
+	}
+
+	return &redisCoordinator{
+		client:   redis.NewClient(&redis.Options{Addr: cfg.addr}),
+		key:      redisKeyPrefix + cfg.leaseName,
+		identity: newIdentity(),
+		ttl:      cfg.ttl,
+		interval: interval,
+		resignCh: make(chan struct{}, 1),
+	}
+}
+
+// redisCoordinatorConfig collects the bits of config.HAConfig the Redis
+// backend needs, so newRedisCoordinator's signature doesn't grow every time
+// another HAConfig field gets used elsewhere.
+type redisCoordinatorConfig struct {
+	addr      string
+	leaseName string
+	ttl       time.Duration
+}
+
+func newIdentity() string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		// Fall back to the hostname alone; collisions are still unlikely and
+		// the lock is still correctness-preserving, just less disambiguated
+		// in logs.
+		hostname, _ := os.Hostname()
+		return hostname
+	}
+	hostname, _ := os.Hostname()
+	return fmt.Sprintf("%s-%s", hostname, hex.EncodeToString(buf))
+}
+
+func (c *redisCoordinator) IsLeader() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.isLeader
+}
+
+func (c *redisCoordinator) Resign() {
+	select {
+	case c.resignCh <- struct{}{}:
+	default:
+	}
+}
+
+func (c *redisCoordinator) Observe() <-chan bool {
+	ch := make(chan bool, 1)
+	c.mu.Lock()
+	ch <- c.isLeader
+	c.observers = append(c.observers, ch)
+	c.mu.Unlock()
+	return ch
+}
+
+func (c *redisCoordinator) notifyObservers(leader bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, ch := range c.observers {
+		select {
+		case ch <- leader:
+		default:
+		}
+	}
+}
+
+func (c *redisCoordinator) Campaign(ctx context.Context, onAcquire func(context.Context), onLose func()) {
+	var leaderCtx context.Context
+	var cancelLeader context.CancelFunc
+	var wg sync.WaitGroup
+
+	becomeLeader := func() {
+		if cancelLeader != nil {
+			return // already running onAcquire
+		}
+		leaderCtx, cancelLeader = context.WithCancel(ctx)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			onAcquire(leaderCtx)
+		}()
+		c.notifyObservers(true)
+	}
+	stepDown := func() {
+		if cancelLeader == nil {
+			return // not currently leader
+		}
+		cancelLeader()
+		wg.Wait()
+		onLose()
+		cancelLeader = nil
+		c.notifyObservers(false)
+	}
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	defer func() {
+		stepDown()
+		c.release()
+	}()
+
+	c.tryAcquireOrRenew()
+	if c.IsLeader() {
+		becomeLeader()
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.resignCh:
+			stepDown()
+			c.release()
+		case <-ticker.C:
+			c.tryAcquireOrRenew()
+			if c.IsLeader() {
+				becomeLeader()
+			} else {
+				stepDown()
+			}
+		}
+	}
+}
+
+// tryAcquireOrRenew attempts SET NX PX to take the lock, or renews the TTL if
+// this identity already holds it.
+func (c *redisCoordinator) tryAcquireOrRenew() {
+	c.mu.RLock()
+	alreadyLeader := c.isLeader
+	c.mu.RUnlock()
+
+	if alreadyLeader {
+		// Renew by extending the TTL, but only while the key still holds
+		// this identity's value. If another replica took over (e.g. a
+		// missed tick let the key expire), renewal fails and leadership is
+		// re-evaluated below.
+		renewed, err := renewScript.Run(context.Background(), c.client, []string{c.key}, c.identity, c.ttl.Milliseconds()).Int()
+		if err == nil && renewed == 1 {
+			return
+		}
+		// Renewal failed: someone else may hold the key now. Fall through to
+		// re-evaluate leadership from scratch below.
+	}
+
+	acquired, err := c.client.SetNX(context.Background(), c.key, c.identity, c.ttl).Result()
+	if err != nil {
+		log.Warn().Err(err).Str("key", c.key).Msg("Failed to reach Redis for HA lock")
+		c.setLeader(false)
+		return
+	}
+
+	if acquired {
+		log.Info().Str("key", c.key).Str("identity", c.identity).Msg("Acquired HA leader lock")
+	}
+	c.setLeader(acquired)
+}
+
+func (c *redisCoordinator) setLeader(leader bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.isLeader = leader
+}
+
+func (c *redisCoordinator) release() {
+	c.mu.Lock()
+	wasLeader := c.isLeader
+	c.isLeader = false
+	c.mu.Unlock()
+
+	if !wasLeader {
+		return
+	}
+
+	if err := releaseScript.Run(context.Background(), c.client, []string{c.key}, c.identity).Err(); err != nil {
+		log.Warn().Err(err).Str("key", c.key).Msg("Failed to release HA leader lock")
+	}
+}
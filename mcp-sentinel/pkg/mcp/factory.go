@@ -6,7 +6,9 @@ import (
 	"time"
 
 	"github.com/jneo8/mcp-sentinel/mcp-sentinel/pkg/config"
+	"github.com/jneo8/mcp-sentinel/mcp-sentinel/pkg/metrics"
 	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/rs/zerolog/log"
 )
@@ -28,23 +30,22 @@ func NewMCPServerManager() *MCPServerManager {
 
 func (m *MCPServerManager) InitializeFromConfig(ctx context.Context, cfg config.Config) error {
 	for _, serverConfig := range cfg.MCPServers {
-		// Only handle stdio servers for now
-		if serverConfig.Type != "stdio" {
-			log.Info().Str("server", serverConfig.Name).Str("type", serverConfig.Type).Msg("Skipping non-stdio server")
-			continue
-		}
-
 		server := &MCPServer{
 			Config: serverConfig,
 		}
 		m.servers[serverConfig.Name] = server
 
-		// Start server if auto-start is enabled
-		if serverConfig.AutoStart {
-			if err := m.startStdioServer(ctx, server); err != nil {
-				log.Error().Err(err).Str("server", serverConfig.Name).Msg("Failed to start stdio MCP server")
-				return err
-			}
+		if !serverConfig.AutoStart {
+			continue
+		}
+
+		if err := m.startServer(ctx, server); err != nil {
+			log.Error().Err(err).Str("server", serverConfig.Name).Msg("Failed to start MCP server")
+			return err
+		}
+
+		if serverConfig.HealthCheckInterval != "" {
+			go m.runHealthCheck(ctx, server)
 		}
 	}
 	return nil
@@ -58,14 +59,23 @@ func (m *MCPServerManager) GetServer(name string) (*MCPServer, error) {
 	return server, nil
 }
 
-func (m *MCPServerManager) startStdioServer(ctx context.Context, server *MCPServer) error {
-	// Parse timeout
-	timeout := 30 * time.Second
-	if server.Config.Timeout != "" {
-		if t, err := time.ParseDuration(server.Config.Timeout); err == nil {
-			timeout = t
-		}
+// startServer creates and initializes the transport-appropriate MCP client
+// for server, dispatching on its configured Type.
+func (m *MCPServerManager) startServer(ctx context.Context, server *MCPServer) error {
+	switch server.Config.Type {
+	case "stdio":
+		return m.startStdioServer(ctx, server)
+	case "http":
+		return m.startHTTPServer(ctx, server)
+	case "sse":
+		return m.startSSEServer(ctx, server)
+	default:
+		return fmt.Errorf("unknown MCP server type: %s", server.Config.Type)
 	}
+}
+
+func (m *MCPServerManager) startStdioServer(ctx context.Context, server *MCPServer) error {
+	timeout := parseTimeout(server.Config.Timeout)
 
 	// Prepare environment
 	env := []string{}
@@ -85,10 +95,70 @@ func (m *MCPServerManager) startStdioServer(ctx context.Context, server *MCPServ
 		return fmt.Errorf("failed to create stdio MCP client: %w", err)
 	}
 
-	server.Client = mcpClient
 	log.Info().Str("server", server.Config.Name).Msg("Created MCP stdio client")
+	return m.initializeClient(ctx, server, mcpClient, timeout)
+}
 
-	// Initialize client with timeout
+func (m *MCPServerManager) startHTTPServer(ctx context.Context, server *MCPServer) error {
+	timeout := parseTimeout(server.Config.Timeout)
+
+	opts := httpClientOptions(server.Config)
+	mcpClient, err := client.NewStreamableHttpClient(server.Config.URL, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to create streamable HTTP MCP client: %w", err)
+	}
+
+	log.Info().Str("server", server.Config.Name).Str("url", server.Config.URL).Msg("Created MCP streamable HTTP client")
+	return m.initializeClient(ctx, server, mcpClient, timeout)
+}
+
+func (m *MCPServerManager) startSSEServer(ctx context.Context, server *MCPServer) error {
+	timeout := parseTimeout(server.Config.Timeout)
+
+	opts := sseClientOptions(server.Config)
+	mcpClient, err := client.NewSSEMCPClient(server.Config.URL, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to create SSE MCP client: %w", err)
+	}
+	if err := mcpClient.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start SSE MCP client: %w", err)
+	}
+
+	log.Info().Str("server", server.Config.Name).Str("url", server.Config.URL).Msg("Created MCP SSE client")
+	return m.initializeClient(ctx, server, mcpClient, timeout)
+}
+
+func httpClientOptions(cfg config.MCPServerConfig) []transport.StreamableHTTPCOption {
+	var opts []transport.StreamableHTTPCOption
+	if len(cfg.Headers) > 0 {
+		opts = append(opts, transport.WithHTTPHeaders(cfg.Headers))
+	}
+	if cfg.BearerToken != "" {
+		opts = append(opts, transport.WithHTTPHeaders(map[string]string{
+			"Authorization": "Bearer " + cfg.BearerToken,
+		}))
+	}
+	return opts
+}
+
+func sseClientOptions(cfg config.MCPServerConfig) []transport.ClientOption {
+	var opts []transport.ClientOption
+	headers := make(map[string]string, len(cfg.Headers)+1)
+	for k, v := range cfg.Headers {
+		headers[k] = v
+	}
+	if cfg.BearerToken != "" {
+		headers["Authorization"] = "Bearer " + cfg.BearerToken
+	}
+	if len(headers) > 0 {
+		opts = append(opts, transport.WithHeaders(headers))
+	}
+	return opts
+}
+
+// initializeClient runs the shared MCP init handshake against mcpClient and,
+// on success, attaches it to server.
+func (m *MCPServerManager) initializeClient(ctx context.Context, server *MCPServer, mcpClient *client.Client, timeout time.Duration) error {
 	initCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
@@ -102,19 +172,94 @@ func (m *MCPServerManager) startStdioServer(ctx context.Context, server *MCPServ
 	initResult, err := mcpClient.Initialize(initCtx, initRequest)
 	if err != nil {
 		mcpClient.Close()
-		server.Client = nil
+		metrics.MCPServerUp.WithLabelValues(server.Config.Name).Set(0)
 		return fmt.Errorf("failed to initialize MCP client: %w", err)
 	}
 
+	server.Client = mcpClient
+	metrics.MCPServerUp.WithLabelValues(server.Config.Name).Set(1)
 	log.Info().
 		Str("server", server.Config.Name).
 		Str("serverName", initResult.ServerInfo.Name).
 		Str("serverVersion", initResult.ServerInfo.Version).
-		Msg("MCP stdio server initialized successfully")
+		Msg("MCP server initialized successfully")
 
 	return nil
 }
 
+func parseTimeout(configured string) time.Duration {
+	timeout := 30 * time.Second
+	if configured != "" {
+		if t, err := time.ParseDuration(configured); err == nil {
+			timeout = t
+		}
+	}
+	return timeout
+}
+
+// runHealthCheck periodically pings server and restarts it with exponential
+// backoff if pings start failing, until ctx is cancelled.
+func (m *MCPServerManager) runHealthCheck(ctx context.Context, server *MCPServer) {
+	interval, err := time.ParseDuration(server.Config.HealthCheckInterval)
+	if err != nil {
+		log.Error().Err(err).Str("server", server.Config.Name).Msg("Invalid health-check-interval, disabling health checks")
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	backoff := time.Second
+	const maxBackoff = 2 * time.Minute
+	// restarting is set while a failed restart is pending retry; Client is
+	// nil then, and the next tick retries after the grown backoff.
+	restarting := false
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if server.Client == nil && !restarting {
+				continue
+			}
+
+			if server.Client != nil {
+				pingCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+				err := server.Client.Ping(pingCtx)
+				cancel()
+
+				if err == nil {
+					backoff = time.Second
+					continue
+				}
+
+				log.Warn().Err(err).Str("server", server.Config.Name).Msg("MCP server failed health check, restarting")
+				metrics.MCPServerUp.WithLabelValues(server.Config.Name).Set(0)
+
+				if stopErr := m.StopServer(server.Config.Name); stopErr != nil {
+					log.Warn().Err(stopErr).Str("server", server.Config.Name).Msg("Failed to stop unhealthy MCP server before restart")
+				}
+			}
+
+			select {
+			case <-time.After(backoff):
+			case <-ctx.Done():
+				return
+			}
+
+			if startErr := m.startServer(ctx, server); startErr != nil {
+				log.Error().Err(startErr).Str("server", server.Config.Name).Msg("Failed to restart MCP server")
+				backoff = min(backoff*2, maxBackoff)
+				restarting = true
+			} else {
+				backoff = time.Second
+				restarting = false
+			}
+		}
+	}
+}
+
 func (m *MCPServerManager) StopServer(name string) error {
 	server, err := m.GetServer(name)
 	if err != nil {
@@ -128,6 +273,7 @@ func (m *MCPServerManager) StopServer(name string) error {
 		server.Client = nil
 	}
 
+	metrics.MCPServerUp.WithLabelValues(name).Set(0)
 	log.Info().Str("server", name).Msg("MCP server stopped")
 	return nil
 }
@@ -138,4 +284,11 @@ func (m *MCPServerManager) StopAllServers() {
 			log.Warn().Err(err).Str("server", name).Msg("Failed to stop MCP server")
 		}
 	}
-}
\ No newline at end of file
+}
+
+func min(a, b time.Duration) time.Duration {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,116 @@
+// Package metrics exposes Sentinel's own operational telemetry as Prometheus
+// metrics, so the same Prometheus Sentinel watches can also scrape it.
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	// WatcherPollTotal counts each watcher poll attempt, labeled by result
+	// ("success" or "error").
+	WatcherPollTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "sentinel_watcher_poll_total",
+		Help: "Total number of watcher polls, by result.",
+	}, []string{"watcher", "result"})
+
+	// WatcherPollDuration tracks how long each watcher poll took.
+	WatcherPollDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "sentinel_watcher_poll_duration_seconds",
+		Help: "Duration of watcher polls in seconds.",
+	}, []string{"watcher"})
+
+	// NotificationsEmittedTotal counts notifications a watcher successfully
+	// sent onto its output channel.
+	NotificationsEmittedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "sentinel_notifications_emitted_total",
+		Help: "Total number of notifications emitted by watchers.",
+	}, []string{"watcher", "resource"})
+
+	// NotificationsDroppedTotal counts notifications that never reached the
+	// incident responder, labeled by reason ("channel_full", "dedup").
+	NotificationsDroppedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "sentinel_notifications_dropped_total",
+		Help: "Total number of notifications dropped before reaching the incident responder.",
+	}, []string{"reason"})
+
+	// DedupStoreOpsTotal counts DedupStore operations, labeled by backend
+	// ("memory", "bolt", "redis") and op ("hit", "miss", "mark", "evict",
+	// "forget").
+	DedupStoreOpsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "sentinel_dedup_store_ops_total",
+		Help: "Total number of DedupStore operations, by backend and op.",
+	}, []string{"backend", "op"})
+
+	// MCPServerUp reports whether an MCP server's client connection is
+	// currently established (1) or not (0).
+	MCPServerUp = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "sentinel_mcp_server_up",
+		Help: "Whether an MCP server connection is currently up.",
+	}, []string{"server"})
+
+	// MCPToolCallsTotal counts MCP tool invocations, labeled by result
+	// ("success" or "error").
+	MCPToolCallsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "sentinel_mcp_tool_calls_total",
+		Help: "Total number of MCP tool calls, by result.",
+	}, []string{"server", "tool", "result"})
+
+	// MCPToolCallDuration tracks how long each MCP tool call took.
+	MCPToolCallDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "sentinel_mcp_tool_call_duration_seconds",
+		Help: "Duration of MCP tool calls in seconds.",
+	}, []string{"server", "tool"})
+
+	// HALeader reports whether this replica currently holds HA leadership (1)
+	// or not (0), fed from ha.Coordinator.Observe().
+	HALeader = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "sentinel_ha_leader",
+		Help: "Whether this replica currently holds HA leadership.",
+	})
+)
+
+// Config configures the /metrics HTTP endpoint.
+type Config struct {
+	Listen string `mapstructure:"listen"`
+}
+
+// Serve starts an HTTP server exposing /metrics on cfg.Listen until ctx is
+// cancelled. It returns immediately; serve errors are logged, not returned,
+// since a metrics outage should not bring Sentinel down.
+func Serve(ctx context.Context, cfg Config) {
+	if cfg.Listen == "" {
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	server := &http.Server{
+		Addr:    cfg.Listen,
+		Handler: mux,
+	}
+
+	go func() {
+		log.Info().Str("listen", cfg.Listen).Msg("Starting metrics endpoint")
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Error().Err(err).Msg("Metrics endpoint failed")
+		}
+	}()
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Warn().Err(err).Msg("Failed to gracefully shut down metrics endpoint")
+		}
+	}()
+}
@@ -0,0 +1,187 @@
+package watcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/jneo8/mcp-sentinel/mcp-sentinel/pkg/config"
+	"github.com/jneo8/mcp-sentinel/mcp-sentinel/pkg/entity"
+	"github.com/rs/zerolog/log"
+)
+
+func init() {
+	RegisterFactory("alertmanager_webhook", newAlertmanagerWebhookWatcherFromConfig)
+}
+
+func newAlertmanagerWebhookWatcherFromConfig(cfg config.WatcherConfig, resources map[string]config.ResourceConfig) (Watcher, error) {
+	return NewAlertmanagerWebhookWatcher(cfg.Name, cfg.Listen, resources), nil
+}
+
+// AlertmanagerAlert mirrors a single alert entry in Alertmanager's v2 webhook payload.
+type AlertmanagerAlert struct {
+	Status       string            `json:"status"`
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations"`
+	StartsAt     time.Time         `json:"startsAt"`
+	EndsAt       time.Time         `json:"endsAt"`
+	GeneratorURL string            `json:"generatorURL"`
+	Fingerprint  string            `json:"fingerprint"`
+}
+
+// AlertmanagerWebhookPayload is the top-level body Alertmanager POSTs to a
+// configured webhook receiver.
+type AlertmanagerWebhookPayload struct {
+	Version           string              `json:"version"`
+	GroupKey          string              `json:"groupKey"`
+	Status            string              `json:"status"`
+	Receiver          string              `json:"receiver"`
+	GroupLabels       map[string]string   `json:"groupLabels"`
+	CommonLabels      map[string]string   `json:"commonLabels"`
+	CommonAnnotations map[string]string   `json:"commonAnnotations"`
+	Alerts            []AlertmanagerAlert `json:"alerts"`
+}
+
+// AlertmanagerWebhookWatcher is a push-based Watcher: it runs an HTTP server
+// and turns incoming Alertmanager webhook payloads into notifications instead
+// of polling for them.
+type AlertmanagerWebhookWatcher struct {
+	name      string
+	listen    string
+	resources map[string]config.ResourceConfig // resource name -> config
+	server    *http.Server
+}
+
+// NewAlertmanagerWebhookWatcher creates a Watcher that listens for Alertmanager
+// v2 webhook callbacks on listen (e.g. ":9096") and matches incoming alerts
+// against resources the same way PrometheusWatcher does.
+func NewAlertmanagerWebhookWatcher(name, listen string, resources map[string]config.ResourceConfig) Watcher {
+	return &AlertmanagerWebhookWatcher{
+		name:      name,
+		listen:    listen,
+		resources: resources,
+	}
+}
+
+func (aw *AlertmanagerWebhookWatcher) Run(ctx context.Context, notificationCh chan<- entity.Notification) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/webhook", aw.handleWebhook(ctx, notificationCh))
+
+	aw.server = &http.Server{
+		Addr:    aw.listen,
+		Handler: mux,
+	}
+
+	log.Info().
+		Str("watcher", aw.name).
+		Str("listen", aw.listen).
+		Msg("Starting Alertmanager webhook watcher")
+
+	go func() {
+		if err := aw.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Error().Err(err).Str("watcher", aw.name).Msg("Alertmanager webhook server failed")
+		}
+	}()
+
+	<-ctx.Done()
+	log.Info().Str("watcher", aw.name).Msg("Alertmanager webhook watcher shutting down")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := aw.server.Shutdown(shutdownCtx); err != nil {
+		log.Warn().Err(err).Str("watcher", aw.name).Msg("Failed to gracefully shut down webhook server")
+	}
+}
+
+func (aw *AlertmanagerWebhookWatcher) handleWebhook(ctx context.Context, notificationCh chan<- entity.Notification) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var payload AlertmanagerWebhookPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			log.Error().Err(err).Str("watcher", aw.name).Msg("Failed to decode Alertmanager webhook payload")
+			http.Error(w, fmt.Sprintf("invalid payload: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		for _, alert := range payload.Alerts {
+			for resourceName, resourceConfig := range aw.resources {
+				if aw.matchesResource(alert, resourceConfig) {
+					notification := aw.createNotification(payload, alert, resourceName)
+
+					select {
+					case notificationCh <- notification:
+						log.Debug().
+							Str("watcher", aw.name).
+							Str("alertname", alert.Labels["alertname"]).
+							Str("resource", resourceName).
+							Str("status", alert.Status).
+							Msg("Sent alert notification")
+					case <-ctx.Done():
+						return
+					default:
+						log.Warn().Str("watcher", aw.name).Msg("Notification channel full, dropping alert")
+					}
+					break // Only send one notification per alert
+				}
+			}
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// matchesResource reuses the same filter semantics as PrometheusWatcher.matchesResource.
+func (aw *AlertmanagerWebhookWatcher) matchesResource(alert AlertmanagerAlert, resource config.ResourceConfig) bool {
+	for filterKey, filterValue := range resource.Filters {
+		alertValue, exists := alert.Labels[filterKey]
+		if !exists {
+			return false
+		}
+
+		// Handle string values
+		if strVal, ok := filterValue.(string); ok {
+			if alertValue != strVal {
+				return false
+			}
+		}
+
+		// Handle slice values (multiple possible values)
+		if sliceVal, ok := filterValue.([]interface{}); ok {
+			found := false
+			for _, val := range sliceVal {
+				if strVal, ok := val.(string); ok && alertValue == strVal {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (aw *AlertmanagerWebhookWatcher) createNotification(payload AlertmanagerWebhookPayload, alert AlertmanagerAlert, resourceName string) entity.Notification {
+	alertName := alert.Labels["alertname"]
+	if alertName == "" {
+		alertName = "UnknownAlert"
+	}
+
+	resource := entity.Resource{
+		Type:        "alertmanager_alert",
+		Name:        resourceName, // Use the resource name instead of alert name
+		Labels:      alert.Labels,
+		Annotations: alert.Annotations,
+		State:       alert.Status,
+		Timestamp:   alert.StartsAt.Format(time.RFC3339),
+		GroupKey:    payload.GroupKey,
+		Fingerprint: alert.Fingerprint,
+	}
+
+	return entity.Notification{
+		Resource: resource,
+		Resolved: alert.Status == "resolved",
+	}
+}
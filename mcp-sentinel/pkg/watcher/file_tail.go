@@ -0,0 +1,147 @@
+package watcher
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"time"
+
+	"github.com/jneo8/mcp-sentinel/mcp-sentinel/pkg/config"
+	"github.com/jneo8/mcp-sentinel/mcp-sentinel/pkg/entity"
+	"github.com/rs/zerolog/log"
+)
+
+func init() {
+	RegisterFactory("file_tail", newFileTailWatcherFromConfig)
+}
+
+func newFileTailWatcherFromConfig(cfg config.WatcherConfig, resources map[string]config.ResourceConfig) (Watcher, error) {
+	pollInterval := 2 * time.Second
+	if cfg.PollInterval != "" {
+		parsed, err := time.ParseDuration(cfg.PollInterval)
+		if err != nil {
+			return nil, fmt.Errorf("invalid poll interval for watcher %s: %w", cfg.Name, err)
+		}
+		pollInterval = parsed
+	}
+
+	return NewFileTailWatcher(cfg.Name, cfg.Endpoint, pollInterval, resources)
+}
+
+// FileTailWatcher tails a log file and emits a notification for each new line
+// that matches one of its resources' "pattern" filter regex.
+type FileTailWatcher struct {
+	name         string
+	path         string
+	pollInterval time.Duration
+	patterns     map[string]*regexp.Regexp // resource name -> compiled pattern
+}
+
+// NewFileTailWatcher creates a Watcher that tails path, polling for new lines
+// every pollInterval. Each resource must set a "pattern" filter with a
+// regular expression to match against new lines.
+func NewFileTailWatcher(name, path string, pollInterval time.Duration, resources map[string]config.ResourceConfig) (Watcher, error) {
+	patterns := make(map[string]*regexp.Regexp)
+	for resourceName, resourceConfig := range resources {
+		patternStr, ok := resourceConfig.Filters["pattern"].(string)
+		if !ok || patternStr == "" {
+			return nil, fmt.Errorf("resource %s has no string \"pattern\" filter for file_tail watcher %s", resourceName, name)
+		}
+
+		pattern, err := regexp.Compile(patternStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern for resource %s: %w", resourceName, err)
+		}
+		patterns[resourceName] = pattern
+	}
+
+	return &FileTailWatcher{
+		name:         name,
+		path:         path,
+		pollInterval: pollInterval,
+		patterns:     patterns,
+	}, nil
+}
+
+func (fw *FileTailWatcher) Run(ctx context.Context, notificationCh chan<- entity.Notification) {
+	log.Info().
+		Str("path", fw.path).
+		Dur("pollInterval", fw.pollInterval).
+		Msg("Starting file tail watcher")
+
+	file, err := os.Open(fw.path)
+	if err != nil {
+		log.Error().Err(err).Str("path", fw.path).Msg("Failed to open file for tailing")
+		return
+	}
+	defer file.Close()
+
+	// Start at the end of the file; we only care about new lines.
+	if _, err := file.Seek(0, io.SeekEnd); err != nil {
+		log.Error().Err(err).Str("path", fw.path).Msg("Failed to seek to end of file")
+		return
+	}
+
+	reader := bufio.NewReader(file)
+	ticker := time.NewTicker(fw.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info().Msg("File tail watcher shutting down")
+			return
+		case <-ticker.C:
+			fw.readNewLines(ctx, reader, notificationCh)
+		}
+	}
+}
+
+func (fw *FileTailWatcher) readNewLines(ctx context.Context, reader *bufio.Reader, notificationCh chan<- entity.Notification) {
+	for {
+		line, err := reader.ReadString('\n')
+		if line != "" {
+			fw.matchLine(ctx, line, notificationCh)
+		}
+		if err != nil {
+			// io.EOF just means no more lines yet; anything else is worth logging.
+			if err != io.EOF {
+				log.Error().Err(err).Str("path", fw.path).Msg("Failed to read line from tailed file")
+			}
+			return
+		}
+	}
+}
+
+func (fw *FileTailWatcher) matchLine(ctx context.Context, line string, notificationCh chan<- entity.Notification) {
+	for resourceName, pattern := range fw.patterns {
+		if !pattern.MatchString(line) {
+			continue
+		}
+
+		notification := entity.Notification{
+			Resource: entity.Resource{
+				Type:        "file_tail_match",
+				Name:        resourceName,
+				Value:       line,
+				Timestamp:   time.Now().Format(time.RFC3339),
+				// Distinct lines matching the same pattern are distinct
+				// incidents, not repeated firings, so dedup must key on the
+				// line content rather than just Type+Name.
+				Fingerprint: entity.FingerprintFrom("file_tail_match", resourceName, line),
+			},
+		}
+
+		select {
+		case notificationCh <- notification:
+			log.Debug().Str("resource", resourceName).Str("path", fw.path).Msg("Sent file tail match notification")
+		case <-ctx.Done():
+			return
+		default:
+			log.Warn().Msg("Notification channel full, dropping file tail match")
+		}
+	}
+}
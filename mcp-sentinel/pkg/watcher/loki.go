@@ -0,0 +1,175 @@
+package watcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/jneo8/mcp-sentinel/mcp-sentinel/pkg/config"
+	"github.com/jneo8/mcp-sentinel/mcp-sentinel/pkg/entity"
+	"github.com/rs/zerolog/log"
+)
+
+func init() {
+	RegisterFactory("loki", newLokiWatcherFromConfig)
+}
+
+func newLokiWatcherFromConfig(cfg config.WatcherConfig, resources map[string]config.ResourceConfig) (Watcher, error) {
+	pollInterval, err := time.ParseDuration(cfg.PollInterval)
+	if err != nil {
+		return nil, fmt.Errorf("invalid poll interval for watcher %s: %w", cfg.Name, err)
+	}
+
+	return NewLokiWatcher(cfg.Name, cfg.Endpoint, pollInterval, resources), nil
+}
+
+// lokiQueryResponse is the subset of Loki's /loki/api/v1/query_range response
+// used to turn matching log lines into notifications.
+type lokiQueryResponse struct {
+	Data struct {
+		Result []struct {
+			Stream map[string]string `json:"stream"`
+			Values [][2]string       `json:"values"` // [unixNanoTimestamp, logLine]
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// LokiWatcher polls a Loki instance's LogQL range query endpoint and turns
+// each matching log line into a notification, using ResourceConfig.Filters
+// as LogQL stream-label selectors.
+type LokiWatcher struct {
+	name         string
+	endpoint     string
+	pollInterval time.Duration
+	client       *http.Client
+	resources    map[string]config.ResourceConfig // resource name -> config
+	lastQueryEnd time.Time
+}
+
+// NewLokiWatcher creates a Watcher that polls endpoint's LogQL range query
+// API once per pollInterval, one query per resource's filters.
+func NewLokiWatcher(name, endpoint string, pollInterval time.Duration, resources map[string]config.ResourceConfig) Watcher {
+	return &LokiWatcher{
+		name:         name,
+		endpoint:     endpoint,
+		pollInterval: pollInterval,
+		resources:    resources,
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		lastQueryEnd: time.Now(),
+	}
+}
+
+func (lw *LokiWatcher) Run(ctx context.Context, notificationCh chan<- entity.Notification) {
+	log.Info().
+		Str("endpoint", lw.endpoint).
+		Dur("pollInterval", lw.pollInterval).
+		Msg("Starting Loki watcher")
+
+	ticker := time.NewTicker(lw.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info().Msg("Loki watcher shutting down")
+			return
+		case <-ticker.C:
+			lw.checkLogs(ctx, notificationCh)
+		}
+	}
+}
+
+func (lw *LokiWatcher) checkLogs(ctx context.Context, notificationCh chan<- entity.Notification) {
+	queryEnd := time.Now()
+	queryStart := lw.lastQueryEnd
+
+	for resourceName, resourceConfig := range lw.resources {
+		logQL := lw.buildLogQL(resourceConfig)
+
+		reqURL := fmt.Sprintf("%s/loki/api/v1/query_range?%s", lw.endpoint, url.Values{
+			"query":     {logQL},
+			"start":     {strconv.FormatInt(queryStart.UnixNano(), 10)},
+			"end":       {strconv.FormatInt(queryEnd.UnixNano(), 10)},
+			"direction": {"forward"},
+		}.Encode())
+
+		req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+		if err != nil {
+			log.Error().Err(err).Str("resource", resourceName).Msg("Failed to create Loki request")
+			continue
+		}
+
+		resp, err := lw.client.Do(req)
+		if err != nil {
+			log.Error().Err(err).Str("resource", resourceName).Msg("Failed to query Loki")
+			continue
+		}
+
+		var queryResp lokiQueryResponse
+		decodeErr := json.NewDecoder(resp.Body).Decode(&queryResp)
+		resp.Body.Close()
+		if decodeErr != nil {
+			log.Error().Err(decodeErr).Str("resource", resourceName).Msg("Failed to decode Loki response")
+			continue
+		}
+
+		for _, stream := range queryResp.Data.Result {
+			for _, value := range stream.Values {
+				notification := lw.createNotification(resourceName, stream.Stream, value)
+
+				select {
+				case notificationCh <- notification:
+					log.Debug().Str("resource", resourceName).Msg("Sent log line notification")
+				case <-ctx.Done():
+					return
+				default:
+					log.Warn().Msg("Notification channel full, dropping log line")
+				}
+			}
+		}
+	}
+
+	lw.lastQueryEnd = queryEnd
+}
+
+// buildLogQL turns a resource's filters into a LogQL stream selector, e.g.
+// {app="ceph",level="error"}.
+func (lw *LokiWatcher) buildLogQL(resource config.ResourceConfig) string {
+	selector := "{"
+	first := true
+	for filterKey, filterValue := range resource.Filters {
+		if strVal, ok := filterValue.(string); ok {
+			if !first {
+				selector += ","
+			}
+			selector += fmt.Sprintf("%s=%q", filterKey, strVal)
+			first = false
+		}
+	}
+	selector += "}"
+	return selector
+}
+
+func (lw *LokiWatcher) createNotification(resourceName string, streamLabels map[string]string, value [2]string) entity.Notification {
+	resource := entity.Resource{
+		Type:      "loki_log_line",
+		Name:      resourceName,
+		Labels:    streamLabels,
+		Value:     value[1],
+		Timestamp: value[0],
+		// Distinct log lines matching the same stream selector are distinct
+		// incidents, not repeated firings, so dedup must key on the line
+		// content rather than just Type+Name+Labels.
+		Fingerprint: entity.FingerprintFrom("loki_log_line", resourceName, value[1]),
+	}
+
+	return entity.Notification{
+		Resource: resource,
+	}
+}
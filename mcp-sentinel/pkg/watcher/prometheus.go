@@ -9,9 +9,23 @@ import (
 
 	"github.com/jneo8/mcp-sentinel/mcp-sentinel/pkg/config"
 	"github.com/jneo8/mcp-sentinel/mcp-sentinel/pkg/entity"
+	"github.com/jneo8/mcp-sentinel/mcp-sentinel/pkg/metrics"
 	"github.com/rs/zerolog/log"
 )
 
+func init() {
+	RegisterFactory("prometheus", newPrometheusWatcherFromConfig)
+}
+
+func newPrometheusWatcherFromConfig(cfg config.WatcherConfig, resources map[string]config.ResourceConfig) (Watcher, error) {
+	pollInterval, err := time.ParseDuration(cfg.PollInterval)
+	if err != nil {
+		return nil, fmt.Errorf("invalid poll interval for watcher %s: %w", cfg.Name, err)
+	}
+
+	return NewPrometheusWatcher(cfg.Name, cfg.Endpoint, pollInterval, resources), nil
+}
+
 type PrometheusAlert struct {
 	Labels      map[string]string `json:"labels"`
 	Annotations map[string]string `json:"annotations"`
@@ -68,22 +82,32 @@ func (pw *PrometheusWatcher) Run(ctx context.Context, notificationCh chan<- enti
 }
 
 func (pw *PrometheusWatcher) checkAlerts(ctx context.Context, notificationCh chan<- entity.Notification) {
+	start := time.Now()
+	result := "success"
+	defer func() {
+		metrics.WatcherPollTotal.WithLabelValues(pw.name, result).Inc()
+		metrics.WatcherPollDuration.WithLabelValues(pw.name).Observe(time.Since(start).Seconds())
+	}()
+
 	alertsURL := fmt.Sprintf("%s/api/v1/alerts", pw.endpoint)
 
 	req, err := http.NewRequestWithContext(ctx, "GET", alertsURL, nil)
 	if err != nil {
+		result = "error"
 		log.Error().Err(err).Msg("Failed to create request")
 		return
 	}
 
 	resp, err := pw.client.Do(req)
 	if err != nil {
+		result = "error"
 		log.Error().Err(err).Msg("Failed to fetch alerts from Prometheus")
 		return
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
+		result = "error"
 		log.Error().
 			Int("statusCode", resp.StatusCode).
 			Msg("Prometheus API returned non-200 status")
@@ -92,6 +116,7 @@ func (pw *PrometheusWatcher) checkAlerts(ctx context.Context, notificationCh cha
 
 	var promResp PrometheusResponse
 	if err := json.NewDecoder(resp.Body).Decode(&promResp); err != nil {
+		result = "error"
 		log.Error().Err(err).Msg("Failed to decode Prometheus response")
 		return
 	}
@@ -108,6 +133,7 @@ func (pw *PrometheusWatcher) checkAlerts(ctx context.Context, notificationCh cha
 
 				select {
 				case notificationCh <- notification:
+					metrics.NotificationsEmittedTotal.WithLabelValues(pw.name, resourceName).Inc()
 					log.Debug().
 						Str("alertname", alert.Labels["alertname"]).
 						Str("resource", resourceName).
@@ -115,6 +141,7 @@ func (pw *PrometheusWatcher) checkAlerts(ctx context.Context, notificationCh cha
 				case <-ctx.Done():
 					return
 				default:
+					metrics.NotificationsDroppedTotal.WithLabelValues("channel_full").Inc()
 					log.Warn().Msg("Notification channel full, dropping alert")
 				}
 				break // Only send one notification per alert
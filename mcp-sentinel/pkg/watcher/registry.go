@@ -0,0 +1,56 @@
+package watcher
+
+import (
+	"fmt"
+
+	"github.com/jneo8/mcp-sentinel/mcp-sentinel/pkg/config"
+)
+
+// Factory builds a Watcher of a specific type from its config and the
+// resources it has been told to watch.
+type Factory func(cfg config.WatcherConfig, resources map[string]config.ResourceConfig) (Watcher, error)
+
+var factories = make(map[string]Factory)
+
+// RegisterFactory makes a watcher type available to CreateWatchersFromConfig.
+// Built-in watchers register themselves via init(); out-of-tree watchers can
+// call this from their own init() as long as their package is imported.
+func RegisterFactory(typeName string, factory Factory) {
+	factories[typeName] = factory
+}
+
+// CreateWatchersFromConfig creates watchers based on configuration
+func CreateWatchersFromConfig(cfg config.Config) ([]Watcher, error) {
+	var watchers []Watcher
+
+	// Create resource map for quick lookup
+	resourceMap := make(map[string]config.ResourceConfig)
+	for _, resource := range cfg.Resources {
+		resourceMap[resource.Name] = resource
+	}
+
+	for _, watcherConfig := range cfg.Watchers {
+		factory, exists := factories[watcherConfig.Type]
+		if !exists {
+			return nil, fmt.Errorf("unknown watcher type: %s", watcherConfig.Type)
+		}
+
+		// Build resource configs for this watcher
+		resourceConfigs := make(map[string]config.ResourceConfig)
+		for _, resourceName := range watcherConfig.Resources {
+			if resource, exists := resourceMap[resourceName]; exists {
+				resourceConfigs[resourceName] = resource
+			} else {
+				return nil, fmt.Errorf("resource %s not found for watcher %s", resourceName, watcherConfig.Name)
+			}
+		}
+
+		w, err := factory(watcherConfig, resourceConfigs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create watcher %s: %w", watcherConfig.Name, err)
+		}
+		watchers = append(watchers, w)
+	}
+
+	return watchers, nil
+}